@@ -58,6 +58,20 @@ func ExpectedApprox[V num.Real](t Tester, expected, actual, epsilon V, name stri
 	return true
 }
 
+// ExpectedAlmostEqual logs a testing error and returns false if the expected and actual floats are not close, per num.AlmostEqual.
+// Unlike ExpectedApprox, this also takes a tolerance relative to the size of the values, so it works for both very large and
+// very small floats. Typically you will not need the return value unless you want to stop testing on failure.
+func ExpectedAlmostEqual[F num.Float](t Tester, expected, actual, absTol, relTol F, name string) bool {
+	if num.AlmostEqual(expected, actual, absTol, relTol) {
+		return true
+	}
+
+	t.Helper() // Marks this func as a Helper, so this error gets logged at the caller's location
+	t.Errorf(`[%s] Expected: "%+v"  Actual: "%+v" ULPs: %d Tolerance: abs %+v rel %+v`, name, expected, actual,
+		num.ULPDistance(expected, actual), absTol, relTol)
+	return false
+}
+
 // This exists because subtractions for unsigned numbers cannot possibly go negative, so I want to just
 // avoid doing the subtraction. Since this is for testing, relative performance for this isn't a big concern.
 func getDelta[V num.Real](a, b V) V {
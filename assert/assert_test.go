@@ -2,6 +2,7 @@ package assert
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -159,3 +160,23 @@ func TestExpectedApprox(t *testing.T) {
 		}
 	}
 }
+
+func TestExpectedAlmostEqual(t *testing.T) {
+	f1 := 0.1
+	c := []testCaseApprox[float64, float64]{
+		{Name: "same value", Expected: 0.3, Actual: 0.3, Pass: true},
+		{Name: "rounding error", Expected: 0.3, Actual: f1 + 0.2, Pass: true, Epsilon: 1e-9},
+		{Name: "rounding error no tolerance", Expected: 0.3, Actual: f1 + 0.2, Pass: false},
+		{Name: "large relative", Expected: 1e20, Actual: 1e20 + 1e10, Pass: true, Epsilon: 1e-9},
+		{Name: "large relative fail", Expected: 1e20, Actual: 1.1e20, Pass: false, Epsilon: 1e-9},
+		{Name: "nan", Expected: math.NaN(), Actual: math.NaN(), Pass: false, Epsilon: 1},
+	}
+
+	for i, tc := range c {
+		// Using the same epsilon for both here, since this is testing the assert and not num.AlmostEqual
+		pass := ExpectedAlmostEqual(&tc, tc.Expected, tc.Actual, tc.Epsilon, tc.Epsilon, tc.Name)
+		if pass != tc.Pass {
+			t.Errorf(`[%d] %s failed: "%s"`, i, tc.Name, tc.loggedMessage)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Package bitset contains sets of small non-negative integers stored as bits, plus helpers for packing bitfields into
+// a single unsigned integer. eg, ECS component masks, tile flags, or which players have seen something.
+//
+// Set grows as needed, and Fixed has a size chosen up front that panics on out of range indexes, which catches bugs
+// like using the wrong component ID. Neither is safe for concurrent use.
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Set is a growable set of non-negative integers. The zero value is an empty set that's ready to use.
+// Memory use is proportional to the largest value in the set, so it's best for small, dense values.
+type Set struct {
+	words []uint64
+}
+
+// New returns a set with room for values up to n-1 without growing.
+func New(n int) *Set {
+	return &Set{words: make([]uint64, wordsFor(n))}
+}
+
+// Of returns a set containing the given values.
+func Of(values ...int) *Set {
+	var s Set
+	for _, v := range values {
+		s.Set(v)
+	}
+	return &s
+}
+
+// Set adds i to the set, growing it if needed. It panics if i is negative.
+func (s *Set) Set(i int) {
+	s.grow(i)
+	s.words[i/64] |= 1 << (i % 64)
+}
+
+// Clear removes i from the set.
+func (s *Set) Clear(i int) {
+	checkIndex(i)
+	if w := i / 64; w < len(s.words) {
+		s.words[w] &^= 1 << (i % 64)
+	}
+}
+
+// Toggle adds i to the set if it's missing, and removes it otherwise.
+func (s *Set) Toggle(i int) {
+	s.grow(i)
+	s.words[i/64] ^= 1 << (i % 64)
+}
+
+// Test returns true if i is in the set.
+func (s *Set) Test(i int) bool {
+	checkIndex(i)
+	w := i / 64
+	return w < len(s.words) && s.words[w]&(1<<(i%64)) != 0
+}
+
+// Count returns the number of values in the set.
+func (s *Set) Count() int {
+	return popCount(s.words)
+}
+
+// All returns the values in the set in increasing order.
+func (s *Set) All() iter.Seq[int] {
+	return setBits(s.words)
+}
+
+// UnionWith adds every value in other to s.
+func (s *Set) UnionWith(other *Set) {
+	if len(other.words) > len(s.words) {
+		s.words = append(s.words, make([]uint64, len(other.words)-len(s.words))...)
+	}
+	for i, w := range other.words {
+		s.words[i] |= w
+	}
+}
+
+// IntersectWith removes every value from s that isn't in other.
+func (s *Set) IntersectWith(other *Set) {
+	for i := range s.words {
+		if i < len(other.words) {
+			s.words[i] &= other.words[i]
+		} else {
+			s.words[i] = 0
+		}
+	}
+}
+
+// DifferenceWith removes every value in other from s.
+func (s *Set) DifferenceWith(other *Set) {
+	for i := range min(len(s.words), len(other.words)) {
+		s.words[i] &^= other.words[i]
+	}
+}
+
+// ContainsAll returns true if every value in other is also in s. eg, does an entity have all the components a system
+// needs.
+func (s *Set) ContainsAll(other *Set) bool {
+	for i, w := range other.words {
+		var mine uint64
+		if i < len(s.words) {
+			mine = s.words[i]
+		}
+		if w&^mine != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects returns true if s and other have any values in common.
+func (s *Set) Intersects(other *Set) bool {
+	for i := range min(len(s.words), len(other.words)) {
+		if s.words[i]&other.words[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns true if s and other contain the same values, regardless of how much room either has.
+func (s *Set) Equal(other *Set) bool {
+	a, b := s.words, other.words
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	for i, w := range a {
+		if i < len(b) {
+			if w != b[i] {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of s.
+func (s *Set) Clone() *Set {
+	return &Set{words: append([]uint64(nil), s.words...)}
+}
+
+// Reset removes every value from the set, keeping its memory.
+func (s *Set) Reset() {
+	clear(s.words)
+}
+
+// String returns the values in the set, eg "{1 4 9}".
+func (s *Set) String() string {
+	return formatSet(s.All())
+}
+
+// grow makes sure there's room for i.
+func (s *Set) grow(i int) {
+	checkIndex(i)
+	if need := i/64 + 1; need > len(s.words) {
+		// Grow by at least double so repeatedly setting the next value is amortized constant time.
+		grown := make([]uint64, max(need, 2*len(s.words)))
+		copy(grown, s.words)
+		s.words = grown
+	}
+}
+
+func checkIndex(i int) {
+	if i < 0 {
+		panic("bitset: negative index " + strconv.Itoa(i))
+	}
+}
+
+func wordsFor(n int) int {
+	return (n + 63) / 64
+}
+
+func popCount(words []uint64) int {
+	count := 0
+	for _, w := range words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// setBits returns the index of each set bit in words, in increasing order.
+func setBits(words []uint64) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for w, word := range words {
+			for ; word != 0; word &= word - 1 { // Clears the lowest set bit
+				if !yield(w*64 + bits.TrailingZeros64(word)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func formatSet(values iter.Seq[int]) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for v := range values {
+		if sb.Len() > 1 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.Itoa(v))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+package bitset
+
+import (
+	"slices"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestSet(t *testing.T) {
+	var s Set
+	ExpectedActual(t, false, s.Test(1000), "zero value is empty")
+	s.Set(3)
+	s.Set(64)
+	s.Set(200)
+	s.Toggle(5)
+	s.Toggle(3)
+	s.Clear(64)
+	s.Clear(10_000) // Out of range is a no-op
+	ExpectedActual(t, []int{5, 200}, slices.Collect(s.All()), "values")
+	ExpectedActual(t, 2, s.Count(), "count")
+	ExpectedActual(t, true, s.Test(200), "test set")
+	ExpectedActual(t, false, s.Test(64), "test cleared")
+	ExpectedActual(t, "{5 200}", s.String(), "string")
+
+	var first []int
+	for v := range Of(1, 2, 3).All() {
+		first = append(first, v)
+		if v == 2 {
+			break
+		}
+	}
+	ExpectedActual(t, []int{1, 2}, first, "break")
+
+	s.Reset()
+	ExpectedActual(t, 0, s.Count(), "reset")
+	ExpectedActual(t, "{}", s.String(), "empty string")
+
+	defer func() {
+		ExpectedActual(t, "bitset: negative index -1", recover(), "negative panics")
+	}()
+	s.Set(-1)
+}
+
+func TestSetOperations(t *testing.T) {
+	a := Of(1, 2, 3, 100)
+	b := Of(2, 3, 4)
+
+	u := a.Clone()
+	u.UnionWith(b)
+	ExpectedActual(t, "{1 2 3 4 100}", u.String(), "union")
+	i := a.Clone()
+	i.IntersectWith(b)
+	ExpectedActual(t, "{2 3}", i.String(), "intersection")
+	d := a.Clone()
+	d.DifferenceWith(b)
+	ExpectedActual(t, "{1 100}", d.String(), "difference")
+	short := b.Clone()
+	short.UnionWith(a)
+	ExpectedActual(t, true, short.Equal(u), "union grows")
+	ExpectedActual(t, "{1 2 3 100}", a.String(), "clone doesn't share")
+
+	ExpectedActual(t, true, a.ContainsAll(Of(1, 100)), "contains all")
+	ExpectedActual(t, false, b.ContainsAll(Of(2, 100)), "contains all beyond length")
+	ExpectedActual(t, true, a.Intersects(b), "intersects")
+	ExpectedActual(t, false, Of(1).Intersects(Of(100)), "doesn't intersect")
+	ExpectedActual(t, true, New(1000).Equal(&Set{}), "equal ignores capacity")
+	ExpectedActual(t, false, Of(1, 100).Equal(Of(1)), "not equal")
+}
+
+func TestFixed(t *testing.T) {
+	f := NewFixed(70)
+	ExpectedActual(t, 70, f.Len(), "len")
+	f.Set(0)
+	f.Set(69)
+	f.Toggle(1)
+	ExpectedActual(t, "{0 1 69}", f.String(), "values")
+	f.Complement()
+	ExpectedActual(t, 67, f.Count(), "complement doesn't set bits past len")
+	ExpectedActual(t, false, f.Test(69), "complemented")
+	f.SetAll()
+	ExpectedActual(t, 70, f.Count(), "set all")
+	ExpectedActual(t, 69, slices.Max(slices.Collect(f.All())), "set all max")
+
+	a, b := NewFixed(8), NewFixed(8)
+	a.Set(1)
+	a.Set(2)
+	b.Set(2)
+	b.Set(3)
+	ExpectedActual(t, true, a.Intersects(b), "intersects")
+	ExpectedActual(t, false, a.ContainsAll(b), "contains all")
+	u := a.Clone()
+	u.UnionWith(b)
+	ExpectedActual(t, "{1 2 3}", u.String(), "union")
+	ExpectedActual(t, true, u.ContainsAll(a), "union contains all")
+	i := a.Clone()
+	i.IntersectWith(b)
+	ExpectedActual(t, "{2}", i.String(), "intersection")
+	a.DifferenceWith(b)
+	ExpectedActual(t, "{1}", a.String(), "difference")
+	ExpectedActual(t, false, a.Equal(NewFixed(9)), "different sizes aren't equal")
+
+	func() {
+		defer func() {
+			ExpectedActual(t, "bitset: index 8 out of range [0, 8)", recover(), "out of range panics")
+		}()
+		a.Set(8)
+	}()
+	defer func() {
+		ExpectedActual(t, "bitset: size mismatch 8 != 9", recover(), "size mismatch panics")
+	}()
+	a.UnionWith(NewFixed(9))
+}
@@ -0,0 +1,89 @@
+package bitset
+
+import (
+	"math/bits"
+	"strconv"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// Field is a range of bits inside an unsigned integer W, used to pack several small values into one.
+// eg, a tile could keep its type in the low 6 bits and its rotation in the next 2, all in a uint8.
+type Field[W num.Unsigned] struct {
+	offset, width uint
+}
+
+// NewField returns the field that's width bits wide, starting offset bits from the least significant bit.
+// It panics if the field is empty or doesn't fit inside W.
+func NewField[W num.Unsigned](offset, width uint) Field[W] {
+	if width == 0 || offset+width > uint(wordBits[W]()) {
+		panic("bitset: field at " + strconv.FormatUint(uint64(offset), 10) + " with width " +
+			strconv.FormatUint(uint64(width), 10) + " doesn't fit in " + strconv.Itoa(wordBits[W]()) + " bits")
+	}
+	return Field[W]{offset: offset, width: width}
+}
+
+// Layout returns consecutive fields with the given widths, starting at the least significant bit.
+// It panics if they don't all fit inside W.
+func Layout[W num.Unsigned](widths ...uint) []Field[W] {
+	fields := make([]Field[W], len(widths))
+	var offset uint
+	for i, width := range widths {
+		fields[i] = NewField[W](offset, width)
+		offset += width
+	}
+	return fields
+}
+
+// Max returns the largest value that fits in the field.
+func (f Field[W]) Max() W {
+	return ^W(0) >> (uint(wordBits[W]()) - f.width)
+}
+
+// Mask returns the bits of the field, in place.
+func (f Field[W]) Mask() W {
+	return f.Max() << f.offset
+}
+
+// Fits returns true if v fits in the field without losing any bits.
+func (f Field[W]) Fits(v W) bool {
+	return v <= f.Max()
+}
+
+// Get returns the value of the field inside packed.
+func (f Field[W]) Get(packed W) W {
+	return packed >> f.offset & f.Max()
+}
+
+// Set returns packed with the field set to v, leaving every other bit as-is.
+// Any bits of v that don't fit are dropped - use Fits first if that's possible.
+func (f Field[W]) Set(packed, v W) W {
+	return packed&^f.Mask() | (v&f.Max())<<f.offset
+}
+
+// Pack returns the values packed into their fields, eg Pack(Layout[uint16](4, 4, 8), a, b, c).
+// It panics if there aren't the same number of fields and values.
+func Pack[W num.Unsigned](fields []Field[W], values ...W) W {
+	if len(fields) != len(values) {
+		panic("bitset: " + strconv.Itoa(len(fields)) + " fields but " + strconv.Itoa(len(values)) + " values")
+	}
+	var packed W
+	for i, f := range fields {
+		packed = f.Set(packed, values[i])
+	}
+	return packed
+}
+
+// Unpack returns the value of each field inside packed, and is the inverse of Pack.
+func Unpack[W num.Unsigned](packed W, fields []Field[W]) []W {
+	values := make([]W, len(fields))
+	for i, f := range fields {
+		values[i] = f.Get(packed)
+	}
+	return values
+}
+
+// wordBits returns the number of bits in W.
+func wordBits[W num.Unsigned]() int {
+	return bits.Len64(uint64(^W(0)))
+}
@@ -0,0 +1,56 @@
+package bitset
+
+import (
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestField(t *testing.T) {
+	kind := NewField[uint8](0, 6)
+	rotation := NewField[uint8](6, 2)
+	ExpectedActual(t, uint8(63), kind.Max(), "max")
+	ExpectedActual(t, uint8(0xc0), rotation.Mask(), "mask")
+
+	var tile uint8
+	tile = kind.Set(tile, 42)
+	tile = rotation.Set(tile, 3)
+	ExpectedActual(t, uint8(0xea), tile, "packed")
+	ExpectedActual(t, uint8(42), kind.Get(tile), "kind")
+	ExpectedActual(t, uint8(3), rotation.Get(tile), "rotation")
+
+	tile = rotation.Set(tile, 5) // Doesn't fit, so only the low 2 bits are kept
+	ExpectedActual(t, uint8(1), rotation.Get(tile), "dropped bits")
+	ExpectedActual(t, uint8(42), kind.Get(tile), "neighbour untouched")
+	ExpectedActual(t, false, rotation.Fits(5), "doesn't fit")
+	ExpectedActual(t, true, rotation.Fits(3), "fits")
+
+	whole := NewField[uint64](0, 64)
+	ExpectedActual(t, ^uint64(0), whole.Max(), "whole word")
+	ExpectedActual(t, uint64(123), whole.Get(whole.Set(7, 123)), "whole word set")
+
+	defer func() {
+		ExpectedActual(t, "bitset: field at 4 with width 5 doesn't fit in 8 bits", recover(), "too wide panics")
+	}()
+	NewField[uint8](4, 5)
+}
+
+func TestPackUnpack(t *testing.T) {
+	fields := Layout[uint32](4, 12, 16)
+	packed := Pack(fields, 0xa, 0xbcd, 0x1234)
+	ExpectedActual(t, uint32(0x1234bcda), packed, "pack")
+	ExpectedActual(t, []uint32{0xa, 0xbcd, 0x1234}, Unpack(packed, fields), "unpack")
+
+	for _, widths := range [][]uint{{0}, {16, 16, 1}} {
+		func() {
+			defer func() {
+				ExpectedActual(t, true, recover() != nil, "bad layout panics")
+			}()
+			Layout[uint32](widths...)
+		}()
+	}
+	defer func() {
+		ExpectedActual(t, "bitset: 3 fields but 2 values", recover(), "count mismatch panics")
+	}()
+	Pack(fields, 1, 2)
+}
@@ -0,0 +1,169 @@
+package bitset
+
+import (
+	"iter"
+	"strconv"
+)
+
+// Fixed is a set of integers in [0, Len()), with the size chosen when it's made. Unlike Set, using a value outside that
+// range panics, and combining two Fixed sets of different sizes panics.
+type Fixed struct {
+	words []uint64
+	n     int
+}
+
+// NewFixed returns an empty set that can hold values in [0, n).
+func NewFixed(n int) *Fixed {
+	if n < 0 {
+		panic("bitset: negative size " + strconv.Itoa(n))
+	}
+	return &Fixed{words: make([]uint64, wordsFor(n)), n: n}
+}
+
+// Len returns the size of the set, which is one more than the largest value it can hold.
+func (f *Fixed) Len() int {
+	return f.n
+}
+
+// Set adds i to the set.
+func (f *Fixed) Set(i int) {
+	f.check(i)
+	f.words[i/64] |= 1 << (i % 64)
+}
+
+// Clear removes i from the set.
+func (f *Fixed) Clear(i int) {
+	f.check(i)
+	f.words[i/64] &^= 1 << (i % 64)
+}
+
+// Toggle adds i to the set if it's missing, and removes it otherwise.
+func (f *Fixed) Toggle(i int) {
+	f.check(i)
+	f.words[i/64] ^= 1 << (i % 64)
+}
+
+// Test returns true if i is in the set.
+func (f *Fixed) Test(i int) bool {
+	f.check(i)
+	return f.words[i/64]&(1<<(i%64)) != 0
+}
+
+// SetAll adds every value in [0, Len()) to the set.
+func (f *Fixed) SetAll() {
+	for i := range f.words {
+		f.words[i] = ^uint64(0)
+	}
+	f.trim()
+}
+
+// Complement flips every bit, so the set contains exactly the values it didn't before.
+func (f *Fixed) Complement() {
+	for i := range f.words {
+		f.words[i] = ^f.words[i]
+	}
+	f.trim()
+}
+
+// Count returns the number of values in the set.
+func (f *Fixed) Count() int {
+	return popCount(f.words)
+}
+
+// All returns the values in the set in increasing order.
+func (f *Fixed) All() iter.Seq[int] {
+	return setBits(f.words)
+}
+
+// UnionWith adds every value in other to f.
+func (f *Fixed) UnionWith(other *Fixed) {
+	f.checkSize(other)
+	for i, w := range other.words {
+		f.words[i] |= w
+	}
+}
+
+// IntersectWith removes every value from f that isn't in other.
+func (f *Fixed) IntersectWith(other *Fixed) {
+	f.checkSize(other)
+	for i, w := range other.words {
+		f.words[i] &= w
+	}
+}
+
+// DifferenceWith removes every value in other from f.
+func (f *Fixed) DifferenceWith(other *Fixed) {
+	f.checkSize(other)
+	for i, w := range other.words {
+		f.words[i] &^= w
+	}
+}
+
+// ContainsAll returns true if every value in other is also in f.
+func (f *Fixed) ContainsAll(other *Fixed) bool {
+	f.checkSize(other)
+	for i, w := range other.words {
+		if w&^f.words[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects returns true if f and other have any values in common.
+func (f *Fixed) Intersects(other *Fixed) bool {
+	f.checkSize(other)
+	for i, w := range other.words {
+		if f.words[i]&w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns true if f and other are the same size and contain the same values.
+func (f *Fixed) Equal(other *Fixed) bool {
+	if f.n != other.n {
+		return false
+	}
+	for i, w := range other.words {
+		if f.words[i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of f.
+func (f *Fixed) Clone() *Fixed {
+	return &Fixed{words: append([]uint64(nil), f.words...), n: f.n}
+}
+
+// Reset removes every value from the set.
+func (f *Fixed) Reset() {
+	clear(f.words)
+}
+
+// String returns the values in the set, eg "{1 4 9}".
+func (f *Fixed) String() string {
+	return formatSet(f.All())
+}
+
+func (f *Fixed) check(i int) {
+	if i < 0 || i >= f.n {
+		panic("bitset: index " + strconv.Itoa(i) + " out of range [0, " + strconv.Itoa(f.n) + ")")
+	}
+}
+
+func (f *Fixed) checkSize(other *Fixed) {
+	if f.n != other.n {
+		panic("bitset: size mismatch " + strconv.Itoa(f.n) + " != " + strconv.Itoa(other.n))
+	}
+}
+
+// trim clears the unused bits in the last word, so they never show up as values.
+func (f *Fixed) trim() {
+	if extra := f.n % 64; extra != 0 {
+		f.words[len(f.words)-1] &= 1<<extra - 1
+	}
+}
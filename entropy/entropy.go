@@ -0,0 +1,49 @@
+// Package entropy contains helpers for code that needs random bytes, so it can take an io.Reader instead of always
+// reading crypto/rand. Production code passes nil (or crypto/rand.Reader), and tests pass Seeded to get the same
+// output every run, eg for snapshot tests that contain IDs or keys.
+package entropy
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	mathrand "math/rand/v2"
+	"sync"
+)
+
+// Read fills b from r, or from crypto/rand if r is nil.
+//
+// Like crypto/rand.Read, this never returns an error - it panics instead if r fails, since there's no sensible way to
+// carry on without randomness.
+func Read(r io.Reader, b []byte) {
+	if r == nil {
+		// crypto/rand.Read() will ALWAYS fill the buffer and not return an error, so I'm intentionally ignoring both return values here
+		_, _ = rand.Read(b)
+		return
+	}
+	if _, err := io.ReadFull(r, b); err != nil {
+		panic("entropy: reading random bytes: " + err.Error())
+	}
+}
+
+// seeded is a ChaCha8 stream behind a mutex, since ChaCha8 isn't safe for concurrent use.
+type seeded struct {
+	mu sync.Mutex
+	c  *mathrand.ChaCha8
+}
+
+// Seeded returns a reader that always produces the same bytes for the same seed. It's safe for concurrent use,
+// though concurrent readers will get the bytes in an unpredictable order.
+//
+// This is ONLY for tests! Anyone who knows or guesses the seed can predict every byte.
+func Seeded(seed uint64) io.Reader {
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], seed)
+	return &seeded{c: mathrand.NewChaCha8(key)}
+}
+
+func (s *seeded) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.c.Read(p)
+}
@@ -0,0 +1,40 @@
+package entropy
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"testing/iotest"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestSeeded(t *testing.T) {
+	a, b := make([]byte, 64), make([]byte, 64)
+	Read(Seeded(42), a)
+	Read(Seeded(42), b)
+	ExpectedActual(t, a, b, "same seed")
+	ExpectedActual(t, []byte{0x22, 0x30, 0x1f, 0xb8}, a[:4], "stable output")
+
+	Read(Seeded(43), b)
+	ExpectedActual(t, false, bytes.Equal(a, b), "different seed")
+
+	// Reading in pieces gives the same stream as reading all at once.
+	r := Seeded(42)
+	pieces := make([]byte, 64)
+	Read(r, pieces[:10])
+	Read(r, pieces[10:])
+	ExpectedActual(t, a, pieces, "pieces")
+}
+
+func TestRead(t *testing.T) {
+	a, b := make([]byte, 32), make([]byte, 32)
+	Read(nil, a)
+	Read(nil, b)
+	ExpectedActual(t, false, bytes.Equal(a, b), "crypto/rand")
+
+	defer func() {
+		ExpectedActual(t, "entropy: reading random bytes: broken", recover(), "failing reader panics")
+	}()
+	Read(iotest.ErrReader(errors.New("broken")), a)
+}
@@ -7,8 +7,10 @@ package hashing
 
 import (
 	"crypto/hmac"
-	"crypto/rand"
 	"crypto/sha256"
+	"io"
+
+	"github.com/seanpfeifer/rigging/entropy"
 )
 
 // HMACKeySize is the expected size of an HMAC hashing key.
@@ -41,10 +43,13 @@ func (key *HMACKey) IsValid(msg string, givenMac []byte) bool {
 // You typically will want to store the output of this (the generated key) and use it repeatedly, hashing messages that you send out
 // and checking validity when returned to you.
 func NewHMACKey() HMACKey {
-	var key HMACKey
-	//  This cannot error, and will crash the program irrecoverably if an error is returned, per Go 1.24 crypto/rand.Read().
-	// crypto/rand.Read() will ALWAYS fill the buffer and not return an error, so I'm intentionally ignoring both return values here
-	_, _ = rand.Read(key[:])
+	return NewHMACKeyFrom(nil)
+}
 
+// NewHMACKeyFrom is like NewHMACKey, but reads the key from r, or crypto/rand if r is nil. This is for tests, where
+// entropy.Seeded gives the same key every run. It panics if r fails.
+func NewHMACKeyFrom(r io.Reader) HMACKey {
+	var key HMACKey
+	entropy.Read(r, key[:])
 	return key
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/entropy"
 )
 
 const (
@@ -25,6 +26,14 @@ func TestIsValid(t *testing.T) {
 	ExpectedActual(t, false, verified, "differing hashes")
 }
 
+func TestNewHMACKeyFrom(t *testing.T) {
+	a := NewHMACKeyFrom(entropy.Seeded(1))
+	b := NewHMACKeyFrom(entropy.Seeded(1))
+	ExpectedActual(t, a, b, "same seed")
+	ExpectedActual(t, a.Hash(dataToBeHashed), b.Hash(dataToBeHashed), "same hash")
+	ExpectedActual(t, false, NewHMACKey() == NewHMACKey(), "random keys")
+}
+
 func BenchmarkHashHMAC(b *testing.B) {
 	key := NewHMACKey()
 
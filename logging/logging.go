@@ -1,30 +1,55 @@
 // Package logging contains very general utility functions for logging.
-// These are meant to be useful only in very basic situations, and they use the standard "log" lib.
+// FatalIfError and LogIfError are meant to be useful only in very basic situations, and they use the standard "log" lib.
+// Setup builds a structured log/slog logger from a Config when you need more than that.
 //
 // Examples of use cases for this:
 //   - Small scripts without the need for complex logging (eg, Advent of Code)
 //   - Prior to setting up your actual log system (eg, failure to set up remote logging)
 package logging
 
-import "log"
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/seanpfeifer/rigging/stackerr"
+)
 
 // FatalIfError will log the error and exit if it is non-nil.
 // This is useful in particular for non-recoverable errors when starting an application.
+// If the error has a stack from the stackerr package, the deepest one is logged too.
 //
-// Note that calls to `defer` will not be triggered by this - no cleanup is done!
+// Note that calls to `defer` will not be triggered by this - no cleanup is done! Use FatalIfErrorExit to run the hooks
+// registered with OnShutdown first.
 func FatalIfError(err error, extraInfo ...any) {
 	if err != nil {
-		log.Fatal(err, extraInfo)
+		log.Print(withStack(fmt.Sprint(err, extraInfo), err))
+		exit(1)
 	}
 }
 
-// LogIfError will log the [error + extra info] if the error is non-nil.
+// LogIfError will log the [error + extra info] if the error is non-nil, followed by the deepest stack from the
+// stackerr package in its chain, if there is one.
 //
 // Returns true if err is non-nil.
 func LogIfError(err error, extraInfo ...any) bool {
 	if err != nil {
-		log.Println(err, extraInfo)
+		logError(err, extraInfo)
 		return true
 	}
 	return false
 }
+
+// logError logs the [error + extra info] like log.Println, along with its stack.
+func logError(err error, extraInfo []any) {
+	log.Print(withStack(fmt.Sprintln(err, extraInfo), err))
+}
+
+// withStack appends the deepest stack in err's chain to msg, on the lines after it.
+func withStack(msg string, err error) string {
+	stack := stackerr.StackOf(err)
+	if stack == nil {
+		return msg
+	}
+	return strings.TrimSuffix(msg, "\n") + "\n" + stack.String()
+}
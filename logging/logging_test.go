@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/stackerr"
+)
+
+// captureStdLog sends the standard "log" package's output to a buffer for the test, without timestamps.
+func captureStdLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	prevOut, prevFlags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(prevOut)
+		log.SetFlags(prevFlags)
+	})
+	return &buf
+}
+
+func openConfig() error {
+	return stackerr.New("no config")
+}
+
+func TestIfError(t *testing.T) {
+	buf := captureStdLog(t)
+	ExpectedActual(t, false, LogIfError(nil), "nil error")
+	ExpectedActual(t, true, LogIfError(errors.New("disk full"), "a.txt"), "error")
+	ExpectedActual(t, "disk full [a.txt]\n", buf.String(), "no stack")
+
+	buf.Reset()
+	LogIfError(fmt.Errorf("starting: %w", stackerr.Wrap(openConfig())))
+	lines := strings.Split(buf.String(), "\n")
+	ExpectedActual(t, "starting: no config []", lines[0], "message")
+	ExpectedActual(t, "github.com/seanpfeifer/rigging/logging.openConfig", lines[1], "deepest stack")
+	ExpectedActual(t, true, strings.HasPrefix(lines[2], "\t"), "file and line")
+
+	code := -1
+	exit = func(c int) { code = c }
+	t.Cleanup(func() { exit = os.Exit })
+	buf.Reset()
+	FatalIfError(nil)
+	ExpectedActual(t, -1, code, "no exit")
+	FatalIfError(openConfig(), "extra")
+	ExpectedActual(t, 1, code, "exit")
+	ExpectedActual(t, true, strings.HasPrefix(buf.String(), "no config [extra]\ngithub.com/seanpfeifer/rigging/logging.openConfig\n"), "fatal stack: "+buf.String())
+}
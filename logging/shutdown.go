@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultHookTimeout is how long a shutdown hook gets when it's registered with a timeout of 0.
+const DefaultHookTimeout = 5 * time.Second
+
+type shutdownHook struct {
+	name     string
+	priority int
+	timeout  time.Duration
+	fn       func(context.Context) error
+}
+
+// registry holds shutdown hooks, and runs them at most once.
+type registry struct {
+	mu    sync.Mutex
+	hooks []shutdownHook
+	once  sync.Once
+}
+
+var hooks registry
+
+// OnShutdown registers fn to run when the program exits through Exit, FatalIfErrorExit, FatalIfErrorSlog, or a signal
+// caught by ExitOnSignal. Use it to flush logs, close databases, and so on.
+//
+// Hooks with a higher priority run first, and hooks with the same priority run in the reverse of the order they were
+// registered, like defer. Each hook gets a context that's cancelled after timeout (or DefaultHookTimeout if it's 0),
+// and is abandoned if it hasn't returned by then so one stuck hook can't stop the others. Errors are logged with slog.
+func OnShutdown(name string, priority int, timeout time.Duration, fn func(ctx context.Context) error) {
+	hooks.register(name, priority, timeout, fn)
+}
+
+// RunShutdownHooks runs the registered shutdown hooks without exiting. They only ever run once, so later calls
+// (including from Exit) do nothing, and calls while they're running wait for them to finish.
+func RunShutdownHooks() {
+	hooks.run()
+}
+
+// Exit runs the shutdown hooks, then exits with the given code.
+func Exit(code int) {
+	hooks.run()
+	exit(code)
+}
+
+// FatalIfErrorExit is like FatalIfError, except that it runs the shutdown hooks before exiting with the given code.
+// Calls to `defer` still won't be triggered, so use OnShutdown for cleanup that has to happen.
+func FatalIfErrorExit(err error, code int, extraInfo ...any) {
+	if err != nil {
+		logError(err, extraInfo)
+		Exit(code)
+	}
+}
+
+// ExitOnSignal runs the shutdown hooks and exits when the program gets SIGINT (eg Ctrl+C) or SIGTERM (eg from
+// Kubernetes or systemd), instead of exiting immediately. The exit code is 128 plus the signal number, which is what
+// a shell reports for a process killed by that signal.
+//
+// Call the returned func to stop handling the signals. It's safe to call more than once.
+func ExitOnSignal() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	done := make(chan struct{})
+	go exitOnSignal(&hooks, signals, done)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+func exitOnSignal(r *registry, signals <-chan os.Signal, done <-chan struct{}) {
+	select {
+	case sig := <-signals:
+		slog.Info("shutting down", "signal", sig.String())
+		r.run()
+		code := 1
+		if s, ok := sig.(syscall.Signal); ok {
+			code = 128 + int(s)
+		}
+		exit(code)
+	case <-done:
+	}
+}
+
+func (r *registry) register(name string, priority int, timeout time.Duration, fn func(context.Context) error) {
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, shutdownHook{name: name, priority: priority, timeout: timeout, fn: fn})
+}
+
+func (r *registry) run() {
+	r.once.Do(func() {
+		r.mu.Lock()
+		ordered := slices.Clone(r.hooks)
+		r.mu.Unlock()
+
+		slices.Reverse(ordered)
+		slices.SortStableFunc(ordered, func(a, b shutdownHook) int { return cmp.Compare(b.priority, a.priority) })
+		for _, h := range ordered {
+			h.run()
+		}
+	})
+}
+
+// run calls the hook, giving up on it after its timeout.
+func (h shutdownHook) run() {
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+
+	result := make(chan error, 1) // Buffered so an abandoned hook can still finish without leaking
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				result <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+		result <- h.fn(ctx)
+	}()
+	select {
+	case err := <-result:
+		if err != nil {
+			slog.Error("shutdown hook failed", "hook", h.name, "error", err)
+		}
+	case <-ctx.Done():
+		slog.Error("shutdown hook timed out", "hook", h.name, "timeout", h.timeout)
+	}
+}
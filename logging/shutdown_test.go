@@ -0,0 +1,151 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+// captureExit swaps out os.Exit and the package's hooks for the test, returning a func to get the exit code.
+func captureExit(t *testing.T) func() int {
+	var mu sync.Mutex
+	code := -1
+	exit = func(c int) {
+		mu.Lock()
+		defer mu.Unlock()
+		code = c
+	}
+	t.Cleanup(func() {
+		exit = os.Exit
+		hooks = registry{}
+	})
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return code
+	}
+}
+
+// captureLogs sends slog and log output to a buffer for the test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	restoreLoggers(t)
+	logger, _ := newLogger(Config{TimeFormat: "none"}, &buf)
+	slog.SetDefault(logger)
+	return &buf
+}
+
+func TestShutdownOrder(t *testing.T) {
+	exitCode := captureExit(t)
+	captureLogs(t)
+
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	OnShutdown("flush logs", -10, 0, record("flush logs"))
+	OnShutdown("close db", 0, 0, record("close db"))
+	OnShutdown("close cache", 0, 0, record("close cache"))
+	OnShutdown("stop server", 10, 0, record("stop server"))
+
+	Exit(3)
+	ExpectedActual(t, []string{"stop server", "close cache", "close db", "flush logs"}, order, "order")
+	ExpectedActual(t, 3, exitCode(), "exit code")
+
+	Exit(4)
+	ExpectedActual(t, 4, len(order), "hooks only run once")
+}
+
+func TestShutdownTimeoutsAndErrors(t *testing.T) {
+	captureExit(t)
+	logs := captureLogs(t)
+
+	release := make(chan struct{})
+	defer close(release)
+	sawDeadline := make(chan bool, 1)
+	var ranAfter bool
+	OnShutdown("stuck", 2, 10*time.Millisecond, func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		sawDeadline <- ok
+		<-release // Ignores the cancellation, so it has to be abandoned
+		return nil
+	})
+	OnShutdown("fails", 1, 0, func(context.Context) error { return errors.New("db gone") })
+	OnShutdown("panics", 1, 0, func(context.Context) error { panic("oops") })
+	OnShutdown("after", 0, 0, func(context.Context) error {
+		ranAfter = true
+		return nil
+	})
+
+	start := time.Now()
+	RunShutdownHooks()
+	ExpectedActual(t, true, time.Since(start) < time.Second, "stuck hook is abandoned")
+	ExpectedActual(t, true, <-sawDeadline, "context has the timeout")
+	ExpectedActual(t, true, ranAfter, "later hooks still run")
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	ExpectedActual(t, []string{
+		`level=ERROR msg="shutdown hook timed out" hook=stuck timeout=10ms`,
+		`level=ERROR msg="shutdown hook failed" hook=panics error="panic: oops"`,
+		`level=ERROR msg="shutdown hook failed" hook=fails error="db gone"`,
+	}, lines, "logs")
+}
+
+func TestFatalIfErrorExit(t *testing.T) {
+	exitCode := captureExit(t)
+	logs := captureLogs(t)
+
+	ran := false
+	OnShutdown("cleanup", 0, 0, func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	FatalIfErrorExit(nil, 2)
+	ExpectedActual(t, false, ran, "no error, no hooks")
+	ExpectedActual(t, -1, exitCode(), "no error, no exit")
+
+	FatalIfErrorExit(errors.New("config missing"), 2, "loading")
+	ExpectedActual(t, true, ran, "hooks ran")
+	ExpectedActual(t, 2, exitCode(), "exit code")
+	ExpectedActual(t, true, strings.Contains(logs.String(), "config missing [loading]"), "logged: "+logs.String())
+}
+
+func TestExitOnSignal(t *testing.T) {
+	exitCode := captureExit(t)
+	captureLogs(t)
+
+	ran := make(chan struct{})
+	OnShutdown("cleanup", 0, 0, func(context.Context) error {
+		close(ran)
+		return nil
+	})
+
+	signals := make(chan os.Signal, 1)
+	finished := make(chan struct{})
+	go func() {
+		exitOnSignal(&hooks, signals, nil)
+		close(finished)
+	}()
+	signals <- syscall.SIGTERM
+	<-finished
+	<-ran
+	ExpectedActual(t, 128+int(syscall.SIGTERM), exitCode(), "exit code")
+
+	// Stopping means a later signal is ignored.
+	stop := ExitOnSignal()
+	stop()
+	stop() // Safe to call twice
+}
@@ -0,0 +1,207 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/seanpfeifer/rigging/stackerr"
+)
+
+// Config describes how to set up a slog.Logger. The zero value logs text at Info level to stderr.
+// It can be loaded directly from a config file, eg with fileload.TOML:
+//
+//	[Log]
+//	Format = "json"
+//	Level = "debug"
+//	Output = "/var/log/service.log"
+type Config struct {
+	// Format is "text" (the default) or "json".
+	Format string
+	// Level is the minimum level to log, eg "debug", "info", "warn", "error", or "info+2".
+	Level slog.Level
+	// AddSource adds the file and line of each log call.
+	AddSource bool
+	// Output is "stderr" (the default), "stdout", or the path of a file to append to.
+	Output string
+	// TimeFormat is a time.Time layout, eg time.RFC3339 or time.Kitchen. Empty uses the handler's default, and "none"
+	// leaves the time out entirely, which is handy when something else adds it, like systemd.
+	TimeFormat string
+}
+
+// Environment variables that override the matching Config fields in Setup.
+const (
+	EnvFormat     = "LOG_FORMAT"
+	EnvLevel      = "LOG_LEVEL"
+	EnvAddSource  = "LOG_SOURCE"
+	EnvOutput     = "LOG_OUTPUT"
+	EnvTimeFormat = "LOG_TIME_FORMAT"
+)
+
+// Setup builds a logger from cfg, with any LOG_* environment variables taking priority over it, and makes it the
+// slog default. This also sends the standard "log" package through it at Error level, so FatalIfError and LogIfError
+// use it too, and still show up when Level is above Info.
+//
+// If Output is a file, it stays open until a later Setup replaces it, or until the shutdown hooks run (see OnShutdown).
+// It's closed after every other hook, so they can still log.
+func Setup(cfg Config) (*slog.Logger, error) {
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	var out io.Writer
+	var file *os.File
+	switch cfg.Output {
+	case "", "stderr":
+		out = os.Stderr
+	case "stdout":
+		out = os.Stdout
+	default:
+		f, err := os.OpenFile(cfg.Output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("opening log output: %w", err)
+		}
+		out, file = f, f
+	}
+
+	logger, err := newLogger(cfg, out)
+	if err != nil {
+		if file != nil {
+			_ = file.Close()
+		}
+		return nil, err
+	}
+	slog.SetDefault(logger)
+	// The "log" package is mostly used for errors here, so don't let a Level of Warn or higher hide them.
+	slog.SetLogLoggerLevel(slog.LevelError)
+	replaceOutput(file)
+	if file != nil {
+		output.hookOnce.Do(func() {
+			OnShutdown("close log output", math.MinInt, 0, func(context.Context) error { return closeOutput() })
+		})
+	}
+	return logger, nil
+}
+
+// output is the file opened by the last Setup, if any. The shutdown hook that closes it is only registered once, since
+// it closes whichever file is current.
+var output struct {
+	mu       sync.Mutex
+	file     *os.File
+	hookOnce sync.Once
+}
+
+// replaceOutput makes f the current output file, closing the previous one. f can be nil.
+func replaceOutput(f *os.File) {
+	output.mu.Lock()
+	prev := output.file
+	output.file = f
+	output.mu.Unlock()
+	if prev != nil {
+		_ = prev.Close()
+	}
+}
+
+// closeOutput closes the current output file, if there is one.
+func closeOutput() error {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+	if output.file == nil {
+		return nil
+	}
+	f := output.file
+	output.file = nil
+	return f.Close()
+}
+
+// newLogger builds a logger from cfg, writing to out.
+func newLogger(cfg Config, out io.Writer) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{
+		Level:     cfg.Level,
+		AddSource: cfg.AddSource,
+	}
+	if cfg.TimeFormat != "" {
+		opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key != slog.TimeKey || len(groups) > 0 {
+				return a
+			}
+			if cfg.TimeFormat == "none" {
+				return slog.Attr{} // Empty attributes are dropped
+			}
+			return slog.String(slog.TimeKey, a.Value.Time().Format(cfg.TimeFormat))
+		}
+	}
+
+	switch strings.ToLower(cfg.Format) {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(out, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(out, opts)), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q, expected \"text\" or \"json\"", cfg.Format)
+}
+
+// applyEnv overrides cfg with any LOG_* environment variables that are set.
+func (cfg *Config) applyEnv() error {
+	if v, ok := os.LookupEnv(EnvFormat); ok {
+		cfg.Format = v
+	}
+	if v, ok := os.LookupEnv(EnvLevel); ok {
+		if err := cfg.Level.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("%s: %w", EnvLevel, err)
+		}
+	}
+	if v, ok := os.LookupEnv(EnvAddSource); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", EnvAddSource, err)
+		}
+		cfg.AddSource = b
+	}
+	if v, ok := os.LookupEnv(EnvOutput); ok {
+		cfg.Output = v
+	}
+	if v, ok := os.LookupEnv(EnvTimeFormat); ok {
+		cfg.TimeFormat = v
+	}
+	return nil
+}
+
+// LogIfErrorSlog logs msg at Error level with err as the "error" attribute, plus any other attributes in args, if err
+// is non-nil. args are key-value pairs or slog.Attrs, like slog.Logger.Error. A nil logger uses slog.Default().
+// If err has a stack from the stackerr package, the deepest one is added as the "stack" attribute.
+//
+// Returns true if err is non-nil.
+func LogIfErrorSlog(logger *slog.Logger, err error, msg string, args ...any) bool {
+	if err == nil {
+		return false
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	attrs := []any{slog.Any("error", err)}
+	if stack := stackerr.StackOf(err); stack != nil {
+		attrs = append(attrs, slog.String("stack", stack.String()))
+	}
+	logger.Error(msg, append(attrs, args...)...)
+	return true
+}
+
+// FatalIfErrorSlog is like LogIfErrorSlog, but runs the shutdown hooks and exits with status 1 after logging, like
+// FatalIfErrorExit. This flushes and closes the output opened by Setup.
+//
+// Note that calls to `defer` will not be triggered by this - use OnShutdown for cleanup that has to happen.
+func FatalIfErrorSlog(logger *slog.Logger, err error, msg string, args ...any) {
+	if LogIfErrorSlog(logger, err, msg, args...) {
+		Exit(1)
+	}
+}
+
+// exit is os.Exit, swapped out in tests.
+var exit = os.Exit
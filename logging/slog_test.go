@@ -0,0 +1,182 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/fileload"
+)
+
+type serviceCfg struct {
+	Log Config
+}
+
+// restoreLoggers puts back the default slog logger, along with the standard "log" package's output and flags, after the
+// test. Setting the slog default redirects "log" too, and restoring a default that wraps "log" doesn't undo that.
+func restoreLoggers(t *testing.T) {
+	prev := slog.Default()
+	prevOut, prevFlags := log.Writer(), log.Flags()
+	prevLevel := slog.SetLogLoggerLevel(slog.LevelInfo)
+	slog.SetLogLoggerLevel(prevLevel)
+	t.Cleanup(func() {
+		slog.SetDefault(prev)
+		slog.SetLogLoggerLevel(prevLevel)
+		log.SetOutput(prevOut)
+		log.SetFlags(prevFlags)
+	})
+}
+
+// useDefault restores the loggers after the test, since Setup replaces them, and closes any file it opened.
+func useDefault(t *testing.T) {
+	restoreLoggers(t)
+	t.Cleanup(func() {
+		replaceOutput(nil)
+		output.hookOnce = sync.Once{}
+		hooks = registry{}
+	})
+}
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(Config{Format: "JSON", Level: slog.LevelWarn, TimeFormat: time.DateOnly}, &buf)
+	ExpectedActual(t, nil, err, "new")
+	logger.Info("hidden")
+	logger.Warn("shown", "count", 3)
+
+	var line map[string]any
+	ExpectedActual(t, nil, json.Unmarshal(buf.Bytes(), &line), "one json line")
+	ExpectedActual[any](t, "shown", line["msg"], "msg")
+	ExpectedActual[any](t, "WARN", line["level"], "level")
+	ExpectedActual[any](t, 3.0, line["count"], "attr")
+	ExpectedActual[any](t, time.Now().Format(time.DateOnly), line["time"], "time format")
+
+	buf.Reset()
+	logger, err = newLogger(Config{TimeFormat: "none", AddSource: true}, &buf)
+	ExpectedActual(t, nil, err, "text")
+	logger.Info("hello")
+	ExpectedActual(t, true, strings.HasPrefix(buf.String(), "level=INFO source="), "no time, with source: "+buf.String())
+	ExpectedActual(t, true, strings.Contains(buf.String(), "slog_test.go"), "source file")
+
+	_, err = newLogger(Config{Format: "xml"}, &buf)
+	ExpectedActual(t, `unknown log format "xml", expected "text" or "json"`, err.Error(), "bad format")
+}
+
+func TestSetupFromTOML(t *testing.T) {
+	useDefault(t)
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "service.log")
+	cfgFile := filepath.Join(dir, "cfg.toml")
+	toml := "[Log]\nFormat = \"json\"\nLevel = \"debug\"\nOutput = '" + logFile + "'\n"
+	ExpectedActual(t, nil, os.WriteFile(cfgFile, []byte(toml), 0o600), "writing toml")
+
+	cfg, _, err := fileload.TOML[serviceCfg](cfgFile)
+	ExpectedActual(t, nil, err, "loading toml")
+	ExpectedActual(t, Config{Format: "json", Level: slog.LevelDebug, Output: logFile}, cfg.Log, "config")
+
+	t.Setenv(EnvLevel, "ERROR")
+	t.Setenv(EnvTimeFormat, "none")
+	logger, err := Setup(cfg.Log)
+	ExpectedActual(t, nil, err, "setup")
+	ExpectedActual(t, logger, slog.Default(), "default")
+	logger.Warn("hidden by env level")
+	slog.Error("shown", "id", 7)
+
+	data, err := os.ReadFile(logFile)
+	ExpectedActual(t, nil, err, "reading log")
+	ExpectedActual(t, `{"level":"ERROR","msg":"shown","id":7}`+"\n", string(data), "log file")
+}
+
+func TestSetupLogsStdErrors(t *testing.T) {
+	useDefault(t)
+	t.Setenv(EnvTimeFormat, "none")
+	logFile := filepath.Join(t.TempDir(), "service.log")
+	_, err := Setup(Config{Level: slog.LevelWarn, Output: logFile})
+	ExpectedActual(t, nil, err, "setup")
+
+	exitCode := captureExit(t)
+	slog.Info("hidden")
+	LogIfError(errors.New("db down"))
+	FatalIfError(errors.New("fatal boom"))
+	ExpectedActual(t, 1, exitCode(), "exit")
+
+	data, err := os.ReadFile(logFile)
+	ExpectedActual(t, nil, err, "reading log")
+	ExpectedActual(t, "level=ERROR msg=\"db down []\"\nlevel=ERROR msg=\"fatal boom []\"\n", string(data), "log file")
+}
+
+func TestSetupClosesOutput(t *testing.T) {
+	useDefault(t)
+	dir := t.TempDir()
+	_, err := Setup(Config{Output: filepath.Join(dir, "first.log")})
+	ExpectedActual(t, nil, err, "first setup")
+	first := output.file
+
+	_, err = Setup(Config{Output: filepath.Join(dir, "second.log")})
+	ExpectedActual(t, nil, err, "second setup")
+	second := output.file
+	ExpectedActual(t, true, errors.Is(first.Close(), os.ErrClosed), "first closed when replaced")
+	ExpectedActual(t, 1, len(hooks.hooks), "one close hook")
+
+	_, err = Setup(Config{Output: filepath.Join(dir, "third.log"), Format: "xml"})
+	ExpectedActual(t, true, err != nil, "bad format")
+	ExpectedActual(t, second, output.file, "failed setup keeps the output")
+
+	RunShutdownHooks()
+	ExpectedActual(t, true, errors.Is(second.Close(), os.ErrClosed), "closed by shutdown hooks")
+	ExpectedActual(t, (*os.File)(nil), output.file, "no output")
+}
+
+func TestSetupEnvErrors(t *testing.T) {
+	useDefault(t)
+	t.Setenv(EnvLevel, "loud")
+	_, err := Setup(Config{})
+	ExpectedActual(t, true, err != nil && strings.HasPrefix(err.Error(), "LOG_LEVEL: "), "bad level")
+
+	t.Setenv(EnvLevel, "info")
+	t.Setenv(EnvAddSource, "maybe")
+	_, err = Setup(Config{})
+	ExpectedActual(t, true, err != nil && strings.HasPrefix(err.Error(), "LOG_SOURCE: "), "bad source")
+
+	t.Setenv(EnvAddSource, "true")
+	t.Setenv(EnvOutput, filepath.Join(t.TempDir(), "missing", "dir", "log"))
+	_, err = Setup(Config{})
+	ExpectedActual(t, true, err != nil, "bad output")
+}
+
+func TestIfErrorSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := newLogger(Config{TimeFormat: "none"}, &buf)
+	ExpectedActual(t, false, LogIfErrorSlog(logger, nil, "nothing"), "nil error")
+	ExpectedActual(t, "", buf.String(), "nothing logged")
+
+	ExpectedActual(t, true, LogIfErrorSlog(logger, errors.New("disk full"), "saving", "file", "a.txt"), "error")
+	ExpectedActual(t, `level=ERROR msg=saving error="disk full" file=a.txt`+"\n", buf.String(), "logged")
+
+	buf.Reset()
+	LogIfErrorSlog(logger, openConfig(), "loading")
+	ExpectedActual(t, true, strings.Contains(buf.String(), ` stack="github.com/seanpfeifer/rigging/logging.openConfig\n\t`), "stack: "+buf.String())
+
+	exitCode := captureExit(t)
+	ran := false
+	OnShutdown("cleanup", 0, 0, func(context.Context) error {
+		ran = true
+		return nil
+	})
+	FatalIfErrorSlog(logger, nil, "fine")
+	ExpectedActual(t, -1, exitCode(), "no exit")
+	ExpectedActual(t, false, ran, "no hooks")
+	FatalIfErrorSlog(logger, errors.New("bad"), "starting")
+	ExpectedActual(t, 1, exitCode(), "exit")
+	ExpectedActual(t, true, ran, "hooks ran")
+}
@@ -0,0 +1,106 @@
+package num
+
+import "unsafe"
+
+// MinOf returns the minimum value representable by the integer type N.
+// eg, MinOf[int8]() is -128 and MinOf[uint8]() is 0.
+func MinOf[N Integer]() N {
+	if isSigned[N]() {
+		return N(1) << (bitSize[N]() - 1)
+	}
+	return 0
+}
+
+// MaxOf returns the maximum value representable by the integer type N.
+// eg, MaxOf[int8]() is 127 and MaxOf[uint8]() is 255.
+func MaxOf[N Integer]() N {
+	return ^MinOf[N]()
+}
+
+// AddChecked returns a+b, and false if the result overflowed N.
+// When false is returned, the result is the wrapped value that Go's normal "+" would have given.
+func AddChecked[N Integer](a, b N) (N, bool) {
+	c := a + b
+	if isSigned[N]() {
+		// Adding a positive number must move us up, and adding a negative number must move us down.
+		return c, (c > a) == (b > 0)
+	}
+	return c, c >= a
+}
+
+// SubChecked returns a-b, and false if the result overflowed N.
+// When false is returned, the result is the wrapped value that Go's normal "-" would have given.
+func SubChecked[N Integer](a, b N) (N, bool) {
+	c := a - b
+	if isSigned[N]() {
+		return c, (c < a) == (b > 0)
+	}
+	return c, a >= b
+}
+
+// MulChecked returns a*b, and false if the result overflowed N.
+// When false is returned, the result is the wrapped value that Go's normal "*" would have given.
+func MulChecked[N Integer](a, b N) (N, bool) {
+	c := a * b
+	if a == 0 || b == 0 {
+		return c, true
+	}
+	// MinOf / -1 doesn't panic in Go, it simply wraps back to MinOf - so the division check below would miss this case.
+	if isSigned[N]() && b == ^N(0) && a == MinOf[N]() {
+		return c, false
+	}
+	return c, c/b == a
+}
+
+// AddSat returns a+b, clamped to [MinOf[N](), MaxOf[N]()] instead of wrapping.
+func AddSat[N Integer](a, b N) N {
+	if c, ok := AddChecked(a, b); ok {
+		return c
+	}
+	if b < 0 {
+		return MinOf[N]()
+	}
+	return MaxOf[N]()
+}
+
+// SubSat returns a-b, clamped to [MinOf[N](), MaxOf[N]()] instead of wrapping.
+// eg, for unsigned types this returns 0 rather than wrapping around when b > a.
+func SubSat[N Integer](a, b N) N {
+	if c, ok := SubChecked(a, b); ok {
+		return c
+	}
+	if isSigned[N]() && b < 0 {
+		return MaxOf[N]()
+	}
+	return MinOf[N]()
+}
+
+// MulSat returns a*b, clamped to [MinOf[N](), MaxOf[N]()] instead of wrapping.
+func MulSat[N Integer](a, b N) N {
+	if c, ok := MulChecked(a, b); ok {
+		return c
+	}
+	if (a < 0) != (b < 0) {
+		return MinOf[N]()
+	}
+	return MaxOf[N]()
+}
+
+// isSigned returns true if N can hold negative values. Unsigned types wrap around instead of going negative.
+func isSigned[N Real]() bool {
+	var zero N
+	return zero-1 < 0
+}
+
+// IsFloat returns true if N is a floating-point type. Integer division truncates, float division doesn't, which is
+// how this tells them apart without reflection.
+func IsFloat[N Real]() bool {
+	var one N = 1
+	return one/2 != 0
+}
+
+// bitSize returns the number of bits in the numeric type N.
+func bitSize[N Real]() uint {
+	var zero N
+	return uint(unsafe.Sizeof(zero)) * 8
+}
@@ -0,0 +1,94 @@
+// This is an external test package because assert imports num, and an internal one would be an import cycle.
+package num_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func TestLimits(t *testing.T) {
+	ExpectedActual(t, int8(math.MinInt8), num.MinOf[int8](), "int8 min")
+	ExpectedActual(t, int8(math.MaxInt8), num.MaxOf[int8](), "int8 max")
+	ExpectedActual(t, int64(math.MinInt64), num.MinOf[int64](), "int64 min")
+	ExpectedActual(t, int64(math.MaxInt64), num.MaxOf[int64](), "int64 max")
+	ExpectedActual(t, uint8(0), num.MinOf[uint8](), "uint8 min")
+	ExpectedActual(t, uint8(math.MaxUint8), num.MaxOf[uint8](), "uint8 max")
+	ExpectedActual(t, uint64(math.MaxUint64), num.MaxOf[uint64](), "uint64 max")
+	ExpectedActual(t, uint(math.MaxUint), num.MaxOf[uint](), "uint max")
+}
+
+func TestIsFloat(t *testing.T) {
+	ExpectedActual(t, true, num.IsFloat[float32](), "float32")
+	ExpectedActual(t, true, num.IsFloat[float64](), "float64")
+	ExpectedActual(t, false, num.IsFloat[int8](), "int8")
+	ExpectedActual(t, false, num.IsFloat[uint64](), "uint64")
+}
+
+// checkedOp is one of the checked/saturating operations along with a reference implementation done using a wider type.
+type checkedOp struct {
+	name     string
+	ref      func(a, b int) int
+	int8s    func(a, b int8) (int8, bool)
+	int8Sat  func(a, b int8) int8
+	uint8s   func(a, b uint8) (uint8, bool)
+	uint8Sat func(a, b uint8) uint8
+}
+
+var checkedOps = []checkedOp{
+	{"add", func(a, b int) int { return a + b }, num.AddChecked[int8], num.AddSat[int8], num.AddChecked[uint8], num.AddSat[uint8]},
+	{"sub", func(a, b int) int { return a - b }, num.SubChecked[int8], num.SubSat[int8], num.SubChecked[uint8], num.SubSat[uint8]},
+	{"mul", func(a, b int) int { return a * b }, num.MulChecked[int8], num.MulSat[int8], num.MulChecked[uint8], num.MulSat[uint8]},
+}
+
+// Exhaustively checks every pair of int8 values against the same math done with an int.
+func TestCheckedInt8(t *testing.T) {
+	for _, op := range checkedOps {
+		for a := math.MinInt8; a <= math.MaxInt8; a++ {
+			for b := math.MinInt8; b <= math.MaxInt8; b++ {
+				want := op.ref(a, b)
+				inRange := want >= math.MinInt8 && want <= math.MaxInt8
+
+				got, ok := op.int8s(int8(a), int8(b))
+				if !ExpectedActual(t, inRange, ok, op.name+" int8 ok") ||
+					!ExpectedActual(t, int8(want), got, op.name+" int8 result") ||
+					!ExpectedActual(t, int8(min(max(want, math.MinInt8), math.MaxInt8)), op.int8Sat(int8(a), int8(b)), op.name+" int8 sat") {
+					t.Logf("a=%d b=%d", a, b)
+					return
+				}
+			}
+		}
+	}
+}
+
+// Exhaustively checks every pair of uint8 values against the same math done with an int.
+func TestCheckedUint8(t *testing.T) {
+	for _, op := range checkedOps {
+		for a := 0; a <= math.MaxUint8; a++ {
+			for b := 0; b <= math.MaxUint8; b++ {
+				want := op.ref(a, b)
+				inRange := want >= 0 && want <= math.MaxUint8
+
+				got, ok := op.uint8s(uint8(a), uint8(b))
+				if !ExpectedActual(t, inRange, ok, op.name+" uint8 ok") ||
+					!ExpectedActual(t, uint8(want), got, op.name+" uint8 result") ||
+					!ExpectedActual(t, uint8(min(max(want, 0), math.MaxUint8)), op.uint8Sat(uint8(a), uint8(b)), op.name+" uint8 sat") {
+					t.Logf("a=%d b=%d", a, b)
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestCheckedInt64Edges(t *testing.T) {
+	_, ok := num.MulChecked[int64](math.MinInt64, -1)
+	ExpectedActual(t, false, ok, "min * -1")
+	_, ok = num.MulChecked[int64](-1, math.MinInt64)
+	ExpectedActual(t, false, ok, "-1 * min")
+	ExpectedActual(t, int64(math.MaxInt64), num.MulSat[int64](math.MinInt64, -1), "min * -1 sat")
+	ExpectedActual(t, int64(math.MinInt64), num.AddSat[int64](math.MinInt64, -1), "min + -1 sat")
+	ExpectedActual(t, uint64(0), num.SubSat[uint64](1, 2), "unsigned sub sat")
+}
@@ -0,0 +1,151 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+var (
+	ErrOverflow  = errors.New("value out of range for target type")
+	ErrSignLoss  = errors.New("negative value cannot be converted to an unsigned type")
+	ErrNotFinite = errors.New("NaN or Inf cannot be converted to an integer type")
+	ErrTruncated = errors.New("value has a fractional part that would be truncated")
+	ErrInexact   = errors.New("value cannot be represented exactly by the target type")
+)
+
+// RoundingMode chooses how ConvertRounded deals with the fractional part of a float being converted to an integer.
+type RoundingMode int
+
+const (
+	RoundHalfEven   RoundingMode = iota // Round to the nearest integer, with ties going to the even one (banker's rounding)
+	RoundFloor                          // Round towards negative infinity
+	RoundCeil                           // Round towards positive infinity
+	RoundTowardZero                     // Drop the fractional part, the same as a normal Go conversion
+)
+
+// Convert converts v to the type To, returning an error rather than silently wrapping or truncating. The errors
+// returned wrap one of ErrOverflow, ErrSignLoss, ErrNotFinite, ErrTruncated, or ErrInexact, so use errors.Is() to check them.
+//
+//	port, err := num.Convert[uint16](cfg.Port) // cfg.Port is an int64
+//
+// Conversions between float types only fail on overflow (eg, 1e300 to float32). Rounding to the nearest representable
+// float is expected there, and NaN/Inf are passed through. Integer to float conversions fail with ErrInexact when
+// the integer is too large to be represented exactly (eg, 1<<53 + 1 to float64).
+func Convert[To, From Real](v From) (To, error) {
+	r := To(v)
+	switch {
+	case IsFloat[From]() && IsFloat[To]():
+		// Values just past MaxFloat32 round down to it, so it's only an overflow if the conversion rounded to Inf.
+		if math.IsInf(float64(r), 0) && !math.IsInf(float64(v), 0) {
+			return 0, convertError[To](ErrOverflow, v)
+		}
+		return r, nil
+
+	case IsFloat[From]():
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, convertError[To](ErrNotFinite, v)
+		}
+		if err := checkFloatRange[To](f); err != nil {
+			return 0, convertError[To](err, v)
+		}
+		if f != math.Trunc(f) {
+			return 0, convertError[To](ErrTruncated, v)
+		}
+		return r, nil
+
+	case IsFloat[To]():
+		// Every integer type is within the range of float32, so the only failure here is losing the low bits.
+		mag, _ := magnitude(v)
+		mantissaBits := 53
+		if bitSize[To]() == 32 {
+			mantissaBits = 24
+		}
+		if bits.Len64(mag)-bits.TrailingZeros64(mag) > mantissaBits {
+			return 0, convertError[To](ErrInexact, v)
+		}
+		return r, nil
+
+	default:
+		// Integer to integer: if it survives the round trip with the same sign, nothing was lost.
+		if From(r) == v && (r < 0) == (v < 0) {
+			return r, nil
+		}
+		if v < 0 && !isSigned[To]() {
+			return 0, convertError[To](ErrSignLoss, v)
+		}
+		return 0, convertError[To](ErrOverflow, v)
+	}
+}
+
+// MustConvert is like Convert, but panics if the conversion fails.
+// This is intended for values that you know are in range, such as constants or already validated input.
+func MustConvert[To, From Real](v From) To {
+	r, err := Convert[To](v)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ConvertRounded is like Convert, but floats being converted to integers are first rounded using the given mode,
+// so ErrTruncated is never returned. All other conversions behave exactly like Convert.
+func ConvertRounded[To, From Real](v From, mode RoundingMode) (To, error) {
+	if !IsFloat[From]() || IsFloat[To]() {
+		return Convert[To](v)
+	}
+
+	f := float64(v)
+	switch mode {
+	case RoundFloor:
+		f = math.Floor(f)
+	case RoundCeil:
+		f = math.Ceil(f)
+	case RoundTowardZero:
+		f = math.Trunc(f)
+	default:
+		f = math.RoundToEven(f)
+	}
+
+	r, err := Convert[To](f)
+	if err != nil {
+		// Report the value we were given rather than the rounded one, since that's what the caller knows about.
+		return 0, convertError[To](errors.Unwrap(err), v)
+	}
+	return r, nil
+}
+
+// checkFloatRange returns an error if the (finite) float f lies outside of the integer type To.
+// Powers of two are exact in a float64, so these bounds are exact as well.
+func checkFloatRange[To Real](f float64) error {
+	n := int(bitSize[To]())
+	if isSigned[To]() {
+		if f < -math.Ldexp(1, n-1) || f >= math.Ldexp(1, n-1) {
+			return ErrOverflow
+		}
+		return nil
+	}
+	if f < 0 && math.Trunc(f) != 0 {
+		return ErrSignLoss
+	}
+	if f >= math.Ldexp(1, n) {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// magnitude returns the absolute value of the integer v as a uint64, and whether v was negative.
+// This is safe for the minimum value of signed types, whose absolute value doesn't fit in the original type.
+func magnitude[N Real](v N) (uint64, bool) {
+	if v < 0 {
+		return -uint64(int64(v)), true
+	}
+	return uint64(v), false
+}
+
+func convertError[To, From Real](err error, v From) error {
+	var to To
+	return fmt.Errorf("%w: %v (%T) to %T", err, v, v, to)
+}
@@ -0,0 +1,134 @@
+package num_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func expectConvertErr(t *testing.T, expected, err error, name string) {
+	t.Helper()
+	if !errors.Is(err, expected) {
+		t.Errorf(`[%s] Expected error: "%v"  Actual: "%v"`, name, expected, err)
+	}
+}
+
+func TestConvertIntegers(t *testing.T) {
+	v8, err := num.Convert[int8](int64(-128))
+	ExpectedActual(t, nil, err, "int64 to int8 min")
+	ExpectedActual(t, int8(-128), v8, "int64 to int8 min value")
+
+	_, err = num.Convert[int8](int64(128))
+	expectConvertErr(t, num.ErrOverflow, err, "int64 to int8 overflow")
+	_, err = num.Convert[int8](int64(-129))
+	expectConvertErr(t, num.ErrOverflow, err, "int64 to int8 underflow")
+
+	_, err = num.Convert[uint32](int64(-1))
+	expectConvertErr(t, num.ErrSignLoss, err, "int64 to uint32 sign loss")
+	_, err = num.Convert[uint64](int8(-1))
+	expectConvertErr(t, num.ErrSignLoss, err, "int8 to uint64 sign loss")
+	_, err = num.Convert[int64](uint64(math.MaxUint64))
+	expectConvertErr(t, num.ErrOverflow, err, "uint64 to int64 overflow")
+
+	u64, err := num.Convert[uint64](int64(math.MaxInt64))
+	ExpectedActual(t, nil, err, "int64 to uint64")
+	ExpectedActual(t, uint64(math.MaxInt64), u64, "int64 to uint64 value")
+}
+
+func TestConvertFloatToInt(t *testing.T) {
+	v, err := num.Convert[int32](float64(-2147483648))
+	ExpectedActual(t, nil, err, "float64 to int32 min")
+	ExpectedActual(t, int32(math.MinInt32), v, "float64 to int32 min value")
+
+	_, err = num.Convert[int32](float64(2147483648))
+	expectConvertErr(t, num.ErrOverflow, err, "float64 to int32 overflow")
+	_, err = num.Convert[int32](1.5)
+	expectConvertErr(t, num.ErrTruncated, err, "float64 to int32 fractional")
+	_, err = num.Convert[int32](math.NaN())
+	expectConvertErr(t, num.ErrNotFinite, err, "NaN to int32")
+	_, err = num.Convert[int64](float32(math.Inf(-1)))
+	expectConvertErr(t, num.ErrNotFinite, err, "-Inf to int64")
+	_, err = num.Convert[uint8](-1.0)
+	expectConvertErr(t, num.ErrSignLoss, err, "float64 to uint8 sign loss")
+	_, err = num.Convert[uint8](-0.5)
+	expectConvertErr(t, num.ErrTruncated, err, "float64 to uint8 small negative")
+	_, err = num.Convert[uint64](math.Ldexp(1, 64))
+	expectConvertErr(t, num.ErrOverflow, err, "float64 to uint64 overflow")
+
+	u, err := num.Convert[uint8](255.0)
+	ExpectedActual(t, nil, err, "float64 to uint8 max")
+	ExpectedActual(t, uint8(255), u, "float64 to uint8 max value")
+}
+
+func TestConvertToFloat(t *testing.T) {
+	f, err := num.Convert[float64](int64(1 << 53))
+	ExpectedActual(t, nil, err, "int64 to float64 exact")
+	ExpectedActual(t, float64(1<<53), f, "int64 to float64 value")
+
+	_, err = num.Convert[float64](int64(1<<53 + 1))
+	expectConvertErr(t, num.ErrInexact, err, "int64 to float64 inexact")
+	_, err = num.Convert[float32](int32(1<<24 + 1))
+	expectConvertErr(t, num.ErrInexact, err, "int32 to float32 inexact")
+	_, err = num.Convert[float64](int64(math.MinInt64))
+	ExpectedActual(t, nil, err, "int64 min to float64")
+	_, err = num.Convert[float64](uint64(math.MaxUint64))
+	expectConvertErr(t, num.ErrInexact, err, "uint64 max to float64")
+
+	_, err = num.Convert[float32](1e300)
+	expectConvertErr(t, num.ErrOverflow, err, "float64 to float32 overflow")
+	// The gap between float32s at MaxFloat32 is 2^104, so anything less than half of that past it rounds down.
+	f32, err := num.Convert[float32](math.MaxFloat32 + 0x1p102)
+	ExpectedActual(t, nil, err, "just past MaxFloat32")
+	ExpectedActual(t, float32(math.MaxFloat32), f32, "just past MaxFloat32 rounds down")
+	f32, err = num.Convert[float32](-math.MaxFloat32 - 0x1p102)
+	ExpectedActual(t, nil, err, "just past -MaxFloat32")
+	ExpectedActual(t, float32(-math.MaxFloat32), f32, "just past -MaxFloat32 rounds up")
+	_, err = num.Convert[float32](math.MaxFloat32 + 0x1p103)
+	expectConvertErr(t, num.ErrOverflow, err, "half a gap past MaxFloat32 rounds to Inf")
+	f32, err = num.Convert[float32](0.1)
+	ExpectedActual(t, nil, err, "float64 to float32 rounding")
+	ExpectedActual(t, float32(0.1), f32, "float64 to float32 rounding value")
+	f32, err = num.Convert[float32](math.Inf(1))
+	ExpectedActual(t, nil, err, "float64 Inf to float32")
+	ExpectedActual(t, true, math.IsInf(float64(f32), 1), "float64 Inf to float32 value")
+}
+
+func TestConvertRounded(t *testing.T) {
+	c := []struct {
+		In       float64
+		Mode     num.RoundingMode
+		Expected int8
+	}{
+		{2.5, num.RoundHalfEven, 2},
+		{3.5, num.RoundHalfEven, 4},
+		{-2.5, num.RoundHalfEven, -2},
+		{2.7, num.RoundFloor, 2},
+		{-2.2, num.RoundFloor, -3},
+		{2.2, num.RoundCeil, 3},
+		{-2.7, num.RoundCeil, -2},
+		{-2.7, num.RoundTowardZero, -2},
+		{127.4, num.RoundHalfEven, 127},
+	}
+	for _, tc := range c {
+		v, err := num.ConvertRounded[int8](tc.In, tc.Mode)
+		ExpectedActual(t, nil, err, "rounding error")
+		ExpectedActual(t, tc.Expected, v, "rounded value")
+	}
+
+	_, err := num.ConvertRounded[int8](127.5, num.RoundHalfEven)
+	expectConvertErr(t, num.ErrOverflow, err, "rounding overflow")
+	_, err = num.ConvertRounded[uint8](-0.5, num.RoundFloor)
+	expectConvertErr(t, num.ErrSignLoss, err, "rounding sign loss")
+}
+
+func TestMustConvert(t *testing.T) {
+	ExpectedActual(t, uint16(8080), num.MustConvert[uint16](8080), "in range")
+
+	defer func() {
+		ExpectedActual(t, true, recover() != nil, "panic on overflow")
+	}()
+	num.MustConvert[uint16](-1)
+}
@@ -0,0 +1,432 @@
+package num
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimalScale is the largest number of digits after the decimal point that a Decimal supports, since 10^18 is the
+// largest power of 10 that fits in an int64.
+const MaxDecimalScale = 18
+
+var ErrInvalidDecimal = errors.New("invalid decimal number")
+
+// Decimal is an exact decimal number, stored as an int64 count of units along with a scale, which is the number of digits
+// after the decimal point. eg, $12.34 is 1234 units at scale 2. This is meant for money and anything else where
+// float rounding is unacceptable.
+//
+// Values loaded from text (or JSON, or TOML) keep the scale that was written, so "12.340" has a scale of 3. Arithmetic
+// results use the larger scale of the two operands, and round using banker's rounding (round half to even) when digits
+// have to be dropped. Use Rescale to pick the scale you want.
+//
+// An int64 holds about 18 significant digits, so at a scale of 2 the range is roughly +/- 92 quadrillion.
+// Anything that would overflow returns an error wrapping ErrOverflow rather than wrapping around.
+//
+//	price, err := num.ParseDecimal("19.99")
+//	total, err := price.Mul(num.NewDecimal(3, 0)) // 59.97
+type Decimal struct {
+	units int64
+	scale uint8
+}
+
+// NewDecimal returns the Decimal units * 10^-scale. eg, NewDecimal(1234, 2) is 12.34.
+// It panics if scale is greater than MaxDecimalScale.
+func NewDecimal(units int64, scale uint8) Decimal {
+	if scale > MaxDecimalScale {
+		panic("num: NewDecimal scale exceeds MaxDecimalScale")
+	}
+	return Decimal{units: units, scale: scale}
+}
+
+// ParseDecimal parses a decimal number such as "12.34", "-0.5", or "100". The scale is the number of digits written
+// after the decimal point. Exponents are not accepted, since money is rarely written that way.
+func ParseDecimal(s string) (Decimal, error) {
+	str := s
+	neg := false
+	if len(str) > 0 && (str[0] == '-' || str[0] == '+') {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+	intPart, frac, hasDot := strings.Cut(str, ".")
+	if (intPart == "" && frac == "") || len(frac) > MaxDecimalScale || (hasDot && frac == "") || !isDigits(intPart) || !isDigits(frac) {
+		return Decimal{}, &strconv.NumError{Func: "ParseDecimal", Num: s, Err: ErrInvalidDecimal}
+	}
+
+	// The digits are parsed as one unsigned number, so that MinInt64 units can be represented.
+	u, err := strconv.ParseUint(intPart+frac, 10, 64)
+	if err != nil || u > 1<<63 || (!neg && u == 1<<63) {
+		return Decimal{}, &strconv.NumError{Func: "ParseDecimal", Num: s, Err: ErrOverflow}
+	}
+	units := int64(u)
+	if neg {
+		units = -units
+	}
+	return Decimal{units: units, scale: uint8(len(frac))}, nil
+}
+
+// Units returns the value as an integer count of the smallest unit, eg 1234 for 12.34.
+func (d Decimal) Units() int64 {
+	return d.units
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() uint8 {
+	return d.scale
+}
+
+// Rescale returns d with the given number of digits after the decimal point. Reducing the scale rounds using banker's
+// rounding, so NewDecimal(125, 2).Rescale(1) is 1.2 and NewDecimal(135, 2).Rescale(1) is 1.4.
+func (d Decimal) Rescale(scale uint8) (Decimal, error) {
+	if scale > MaxDecimalScale {
+		return Decimal{}, decimalError(ErrOverflow, "rescale", d)
+	}
+	if scale <= d.scale {
+		return Decimal{units: roundHalfEven(d.units, pow10[d.scale-scale]), scale: scale}, nil
+	}
+	units, ok := MulChecked(d.units, pow10[scale-d.scale])
+	if !ok {
+		return Decimal{}, decimalError(ErrOverflow, "rescale", d)
+	}
+	return Decimal{units: units, scale: scale}, nil
+}
+
+// Add returns d+o, at the larger of the two scales.
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	a, b, err := matchScales(d, o)
+	if err != nil {
+		return Decimal{}, err
+	}
+	units, ok := AddChecked(a.units, b.units)
+	if !ok {
+		return Decimal{}, decimalError(ErrOverflow, "add", d)
+	}
+	return Decimal{units: units, scale: a.scale}, nil
+}
+
+// Sub returns d-o, at the larger of the two scales.
+func (d Decimal) Sub(o Decimal) (Decimal, error) {
+	a, b, err := matchScales(d, o)
+	if err != nil {
+		return Decimal{}, err
+	}
+	units, ok := SubChecked(a.units, b.units)
+	if !ok {
+		return Decimal{}, decimalError(ErrOverflow, "sub", d)
+	}
+	return Decimal{units: units, scale: a.scale}, nil
+}
+
+// Mul returns d*o, at the larger of the two scales, using banker's rounding for any digits dropped.
+func (d Decimal) Mul(o Decimal) (Decimal, error) {
+	scale := max(d.scale, o.scale)
+	// The exact product has a scale of d.scale + o.scale, so we drop the extra digits from that.
+	drop := d.scale + o.scale - scale
+	if units, ok := MulChecked(d.units, o.units); ok {
+		return Decimal{units: roundHalfEven(units, pow10[drop]), scale: scale}, nil
+	}
+
+	// The product overflowed, but the result may still fit after dropping digits.
+	p := new(big.Int).Mul(big.NewInt(d.units), big.NewInt(o.units))
+	return bigToDecimal(p, big.NewInt(pow10[drop]), scale, "mul", d)
+}
+
+// Div returns d/o, at the larger of the two scales, using banker's rounding. It panics if o is zero, like integer division.
+func (d Decimal) Div(o Decimal) (Decimal, error) {
+	if o.units == 0 {
+		panic("num: Decimal division by zero")
+	}
+	scale := max(d.scale, o.scale)
+	// d/o = (d.units / o.units) * 10^(o.scale - d.scale), and we want that multiplied by 10^scale.
+	// scale >= d.scale, so the exponent is never negative.
+	n := new(big.Int).Mul(big.NewInt(d.units), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale+o.scale-d.scale)), nil))
+	return bigToDecimal(n, big.NewInt(o.units), scale, "div", d)
+}
+
+// Neg returns -d. This only fails when the units are math.MinInt64.
+func (d Decimal) Neg() (Decimal, error) {
+	if d.units == math.MinInt64 {
+		return Decimal{}, decimalError(ErrOverflow, "neg", d)
+	}
+	return Decimal{units: -d.units, scale: d.scale}, nil
+}
+
+// Cmp compares d and o, returning -1 if d < o, 0 if d == o, and +1 if d > o. Values are compared exactly, regardless of
+// scale, so 1.5 and 1.50 are equal.
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, err := matchScales(d, o)
+	if err != nil {
+		// Too big to match scales, so fall back to exact comparison via big.Rat.
+		return d.Rat().Cmp(o.Rat())
+	}
+	switch {
+	case a.units < b.units:
+		return -1
+	case a.units > b.units:
+		return 1
+	}
+	return 0
+}
+
+// Sign returns -1, 0, or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero returns true if d is zero, at any scale.
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+// Split divides d into n parts that are as equal as possible and add up to exactly d. Any leftover units are given
+// one at a time to the first parts, so $10.00 split 3 ways is [3.34, 3.33, 3.33]. It panics if n < 1.
+func (d Decimal) Split(n int) []Decimal {
+	if n < 1 {
+		panic("num: Decimal.Split requires n >= 1")
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return d.Allocate(ratios...)
+}
+
+// Allocate divides d into parts proportional to the given ratios, which add up to exactly d. eg, $100.00 allocated
+// with ratios 1, 2 is [33.34, 66.66]. Leftover units from rounding down are given one at a time to the first parts.
+// It panics if there are no ratios, any ratio is negative, or they are all zero.
+func (d Decimal) Allocate(ratios ...int64) []Decimal {
+	total := new(big.Int)
+	for _, r := range ratios {
+		if r < 0 {
+			panic("num: Decimal.Allocate ratios must not be negative")
+		}
+		total.Add(total, big.NewInt(r))
+	}
+	if total.Sign() == 0 {
+		panic("num: Decimal.Allocate requires a positive ratio")
+	}
+
+	// Work with the magnitude so that the leftover is always handed out in the same direction, and apply the sign after.
+	mag, neg := magnitude(d.units)
+	bigMag := new(big.Int).SetUint64(mag)
+	parts := make([]Decimal, len(ratios))
+	remaining := mag
+	for i, r := range ratios {
+		share := new(big.Int).Mul(bigMag, big.NewInt(r))
+		share.Quo(share, total)
+		parts[i].units = int64(share.Uint64()) // Each share <= mag, so this only wraps for MinInt64, which is fixed by the sign below
+		parts[i].scale = d.scale
+		remaining -= share.Uint64()
+	}
+	for i := 0; remaining > 0; i = (i + 1) % len(parts) {
+		if ratios[i] > 0 {
+			parts[i].units++
+			remaining--
+		}
+	}
+	if neg {
+		for i := range parts {
+			parts[i].units = -parts[i].units
+		}
+	}
+	return parts
+}
+
+// Float64 returns the nearest float64 to d. This is for display or for handing off to other code - don't do math with it!
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Rat returns d as a newly allocated big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(d.units), big.NewInt(pow10[d.scale]))
+}
+
+// String returns d with exactly Scale() digits after the decimal point, eg "12.30" or "-0.05".
+func (d Decimal) String() string {
+	mag, neg := magnitude(d.units)
+	s := strconv.FormatUint(mag, 10)
+	if d.scale > 0 {
+		if len(s) <= int(d.scale) {
+			s = strings.Repeat("0", int(d.scale)-len(s)+1) + s
+		}
+		s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	}
+	if neg {
+		return "-" + s
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler, using the same format as String.
+// This also means JSON encodes a Decimal as a string, so that other languages don't parse it as a float.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, using ParseDecimal.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// UnmarshalJSON accepts either a JSON string ("12.34") or a JSON number (12.34 or 1.5e3). Numbers are parsed from their
+// text, so they never go through a float, and an exponent is only accepted if the result is exact within
+// MaxDecimalScale. A JSON null leaves d unchanged, per the encoding/json convention.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(s))
+	}
+	v, err := parseJSONNumber(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// UnmarshalTOML accepts a TOML string ("12.34"), integer (12), or float (12.34), implementing toml.Unmarshaler.
+// TOML floats have already been parsed as a float64, so we use the shortest string that gives the same float back. That's
+// what was written in the file, unless it had more digits than a float64 can hold - use a string for those.
+func (d *Decimal) UnmarshalTOML(v any) error {
+	switch val := v.(type) {
+	case string:
+		return d.UnmarshalText([]byte(val))
+	case int64:
+		*d = Decimal{units: val}
+		return nil
+	case float64:
+		return d.UnmarshalText([]byte(strconv.FormatFloat(val, 'f', -1, 64)))
+	}
+	return fmt.Errorf("%w: unsupported TOML type %T", ErrInvalidDecimal, v)
+}
+
+// parseJSONNumber parses a JSON number, which is a decimal with an optional exponent, eg "1.5e3" or "25E-2".
+// The exponent moves the decimal point, so "1.5e3" is 1500 at scale 0 and "25E-2" is 0.25 at scale 2.
+func parseJSONNumber(s string) (Decimal, error) {
+	mantissa, expStr, hasExp := strings.Cut(strings.ToLower(s), "e")
+	d, err := ParseDecimal(mantissa)
+	if err != nil || !hasExp {
+		return d, err
+	}
+	// Atoi gives the closest int for a range error, which the clamp below handles like any other huge exponent.
+	exp, err := strconv.Atoi(expStr)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return Decimal{}, &strconv.NumError{Func: "ParseDecimal", Num: s, Err: ErrInvalidDecimal}
+	}
+	// An exponent this far out is either zero or out of range no matter what the digits are, and clamping it keeps the
+	// scale math from overflowing.
+	const maxExp = 2*MaxDecimalScale + 20
+	exp = min(max(exp, -maxExp), maxExp)
+
+	scale := int(d.scale) - exp
+	units := d.units
+	// Drop trailing zeros that would push the scale too high, since they don't change the value.
+	for scale > MaxDecimalScale && units%10 == 0 && units != 0 {
+		units /= 10
+		scale--
+	}
+	switch {
+	case units == 0:
+		return Decimal{scale: uint8(min(max(scale, 0), MaxDecimalScale))}, nil
+	case scale > MaxDecimalScale:
+		return Decimal{}, &strconv.NumError{Func: "ParseDecimal", Num: s, Err: fmt.Errorf("%w: more than %d digits after the decimal point", ErrInvalidDecimal, MaxDecimalScale)}
+	case scale < 0:
+		if -scale > MaxDecimalScale {
+			return Decimal{}, &strconv.NumError{Func: "ParseDecimal", Num: s, Err: ErrOverflow}
+		}
+		var ok bool
+		if units, ok = MulChecked(units, pow10[-scale]); !ok {
+			return Decimal{}, &strconv.NumError{Func: "ParseDecimal", Num: s, Err: ErrOverflow}
+		}
+		scale = 0
+	}
+	return Decimal{units: units, scale: uint8(scale)}, nil
+}
+
+// pow10 is every power of 10 that fits in an int64, indexed by exponent.
+var pow10 = [MaxDecimalScale + 1]int64{
+	1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// matchScales returns a and b rescaled to the larger of their two scales.
+func matchScales(a, b Decimal) (Decimal, Decimal, error) {
+	var err error
+	if a.scale < b.scale {
+		a, err = a.Rescale(b.scale)
+	} else if b.scale < a.scale {
+		b, err = b.Rescale(a.scale)
+	}
+	return a, b, err
+}
+
+// roundHalfEven returns n/div, rounded to the nearest integer with ties going to the even one. div must be positive.
+func roundHalfEven(n, div int64) int64 {
+	q, r := n/div, n%div
+	rMag, _ := magnitude(r)
+	// div is at most 10^18, so doubling r can't overflow.
+	if twice := 2 * rMag; twice > uint64(div) || (twice == uint64(div) && q%2 != 0) {
+		if n < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// bigToDecimal returns n/div at the given scale, using banker's rounding, or an overflow error if it doesn't fit.
+func bigToDecimal(n, div *big.Int, scale uint8, op string, d Decimal) (Decimal, error) {
+	if div.Sign() < 0 {
+		n.Neg(n)
+		div = new(big.Int).Neg(div)
+	}
+	q, r := new(big.Int).QuoRem(n, div, new(big.Int))
+	switch r.Abs(r).Lsh(r, 1).Cmp(div) {
+	case 1:
+		q.Add(q, big.NewInt(int64(n.Sign())))
+	case 0:
+		if q.Bit(0) != 0 {
+			q.Add(q, big.NewInt(int64(n.Sign())))
+		}
+	}
+	if !q.IsInt64() {
+		return Decimal{}, decimalError(ErrOverflow, op, d)
+	}
+	return Decimal{units: q.Int64(), scale: scale}, nil
+}
+
+func decimalError(err error, op string, d Decimal) error {
+	return fmt.Errorf("%w: Decimal %s on %s", err, op, d)
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+package num_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/fileload"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func mustDecimal(t *testing.T, s string) num.Decimal {
+	t.Helper()
+	d, err := num.ParseDecimal(s)
+	ExpectedActual(t, nil, err, "parsing "+s)
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	c := []struct {
+		In    string
+		Units int64
+		Scale uint8
+		Out   string
+	}{
+		{"12.34", 1234, 2, "12.34"},
+		{"-0.05", -5, 2, "-0.05"},
+		{"+7", 7, 0, "7"},
+		{".5", 5, 1, "0.5"},
+		{"12.340", 12340, 3, "12.340"},
+		{"-9223372036854775808", math.MinInt64, 0, "-9223372036854775808"},
+		{"0.000000000000000001", 1, 18, "0.000000000000000001"},
+	}
+	for _, tc := range c {
+		d, err := num.ParseDecimal(tc.In)
+		ExpectedActual(t, nil, err, tc.In+" error")
+		ExpectedActual(t, tc.Units, d.Units(), tc.In+" units")
+		ExpectedActual(t, tc.Scale, d.Scale(), tc.In+" scale")
+		ExpectedActual(t, tc.Out, d.String(), tc.In+" string")
+	}
+
+	for _, bad := range []string{"", "-", ".", "5.", "1e5", "1,000", "0x10", "1.2.3", "0.0000000000000000001"} {
+		_, err := num.ParseDecimal(bad)
+		ExpectedActual(t, true, errors.Is(err, num.ErrInvalidDecimal), "invalid "+bad)
+	}
+	_, err := num.ParseDecimal("9223372036854775808")
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "overflow")
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := mustDecimal(t, "10.25"), mustDecimal(t, "0.5")
+
+	sum, err := a.Add(b)
+	ExpectedActual(t, nil, err, "add error")
+	ExpectedActual(t, "10.75", sum.String(), "add")
+	diff, err := b.Sub(a)
+	ExpectedActual(t, nil, err, "sub error")
+	ExpectedActual(t, "-9.75", diff.String(), "sub")
+	prod, err := a.Mul(b)
+	ExpectedActual(t, nil, err, "mul error")
+	ExpectedActual(t, "5.12", prod.String(), "mul rounds half to even") // 5.125
+	quot, err := a.Div(mustDecimal(t, "3"))
+	ExpectedActual(t, nil, err, "div error")
+	ExpectedActual(t, "3.42", quot.String(), "div")
+	quot, err = mustDecimal(t, "1").Div(mustDecimal(t, "0.08"))
+	ExpectedActual(t, nil, err, "div scale error")
+	ExpectedActual(t, "12.50", quot.String(), "div scale")
+
+	ExpectedActual(t, 0, mustDecimal(t, "1.5").Cmp(mustDecimal(t, "1.50")), "cmp across scales")
+	ExpectedActual(t, -1, mustDecimal(t, "-1.5").Cmp(mustDecimal(t, "1.49")), "cmp less")
+	ExpectedActual(t, 1, num.NewDecimal(math.MaxInt64, 0).Cmp(num.NewDecimal(1, 18)), "cmp too large to rescale")
+
+	_, err = num.NewDecimal(math.MaxInt64, 2).Add(num.NewDecimal(1, 2))
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "add overflow")
+	_, err = num.NewDecimal(math.MaxInt64, 0).Add(num.NewDecimal(1, 2))
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "rescale overflow")
+	_, err = num.NewDecimal(math.MaxInt64, 0).Mul(num.NewDecimal(2, 0))
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "mul overflow")
+	_, err = num.NewDecimal(math.MinInt64, 0).Neg()
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "neg overflow")
+
+	// The intermediate product overflows an int64 here, but the result doesn't.
+	large, err := num.NewDecimal(math.MaxInt64/10, 18).Mul(num.NewDecimal(100, 1))
+	ExpectedActual(t, nil, err, "large mul error")
+	ExpectedActual(t, num.NewDecimal(math.MaxInt64/10*10, 18), large, "large mul")
+}
+
+func TestDecimalRescale(t *testing.T) {
+	c := []struct {
+		In       string
+		Scale    uint8
+		Expected string
+	}{
+		{"1.25", 1, "1.2"},
+		{"1.35", 1, "1.4"},
+		{"-1.25", 1, "-1.2"},
+		{"-1.35", 1, "-1.4"},
+		{"1.251", 1, "1.3"},
+		{"0.5", 0, "0"},
+		{"1.5", 0, "2"},
+		{"2.5", 0, "2"},
+		{"1.5", 3, "1.500"},
+	}
+	for _, tc := range c {
+		d, err := mustDecimal(t, tc.In).Rescale(tc.Scale)
+		ExpectedActual(t, nil, err, tc.In+" rescale error")
+		ExpectedActual(t, tc.Expected, d.String(), tc.In+" rescale")
+	}
+}
+
+func TestDecimalAllocate(t *testing.T) {
+	parts := mustDecimal(t, "10.00").Split(3)
+	ExpectedActual(t, []string{"3.34", "3.33", "3.33"}, decimalStrings(parts), "split")
+
+	parts = mustDecimal(t, "-10.00").Split(3)
+	ExpectedActual(t, []string{"-3.34", "-3.33", "-3.33"}, decimalStrings(parts), "negative split")
+
+	parts = mustDecimal(t, "100.00").Allocate(1, 2)
+	ExpectedActual(t, []string{"33.34", "66.66"}, decimalStrings(parts), "allocate")
+
+	parts = mustDecimal(t, "0.05").Allocate(0, 1, 1)
+	ExpectedActual(t, []string{"0.00", "0.03", "0.02"}, decimalStrings(parts), "allocate with zero ratio")
+
+	// Whatever the split, nothing should be lost.
+	total := mustDecimal(t, "1234.57")
+	for n := 1; n < 50; n++ {
+		var sum num.Decimal
+		for _, p := range total.Split(n) {
+			sum, _ = sum.Add(p)
+		}
+		if !ExpectedActual(t, 0, total.Cmp(sum), "split sums to total") {
+			t.Logf("n=%d", n)
+			return
+		}
+	}
+}
+
+func decimalStrings(parts []num.Decimal) []string {
+	var out []string
+	for _, p := range parts {
+		out = append(out, p.String())
+	}
+	return out
+}
+
+type invoice struct {
+	Total    num.Decimal
+	Tax      num.Decimal
+	Discount num.Decimal
+}
+
+func TestDecimalFileLoad(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "invoice.json")
+	tomlFile := filepath.Join(dir, "invoice.toml")
+	ExpectedActual(t, nil, os.WriteFile(jsonFile, []byte(`{"Total": "19.99", "Tax": 1.60, "Discount": 2}`), 0o600), "writing json")
+	ExpectedActual(t, nil, os.WriteFile(tomlFile, []byte("Total = \"19.99\"\nTax = 1.60\nDiscount = 2\n"), 0o600), "writing toml")
+
+	inv, err := fileload.JSON[invoice](jsonFile)
+	ExpectedActual(t, nil, err, "loading json")
+	ExpectedActual(t, []string{"19.99", "1.60", "2"}, decimalStrings([]num.Decimal{inv.Total, inv.Tax, inv.Discount}), "json values")
+
+	inv, _, err = fileload.TOML[invoice](tomlFile)
+	ExpectedActual(t, nil, err, "loading toml")
+	// TOML floats go through a float64, so the trailing zero isn't kept - the value is still exact.
+	ExpectedActual(t, []string{"19.99", "1.6", "2"}, decimalStrings([]num.Decimal{inv.Total, inv.Tax, inv.Discount}), "toml values")
+
+	var d num.Decimal
+	ExpectedActual(t, nil, json.Unmarshal([]byte("null"), &d), "json null")
+
+	for in, want := range map[string]string{
+		`1.5e3`:       "1500",
+		`25E-2`:       "0.25",
+		`-1.5E+1`:     "-15",
+		`0e99`:        "0",
+		`1.20e-17`:    "0.000000000000000012", // The trailing zero is dropped to fit
+		`120e-19`:     "0.000000000000000012",
+		`"12.5"`:      "12.5",
+		`"\u00312.5"`: "12.5", // Escaped strings are unescaped
+	} {
+		ExpectedActual(t, nil, json.Unmarshal([]byte(in), &d), "json "+in)
+		ExpectedActual(t, want, d.String(), "json "+in)
+	}
+	for _, in := range []string{`0e-9223372036854775808`, `0e99999999999999999999`} {
+		ExpectedActual(t, nil, json.Unmarshal([]byte(in), &d), "json "+in)
+		ExpectedActual(t, true, d.IsZero(), "json "+in)
+	}
+	ExpectedActual(t, true, errors.Is(json.Unmarshal([]byte(`1e-9223372036854775808`), &d), num.ErrInvalidDecimal), "json huge negative exponent")
+	ExpectedActual(t, true, errors.Is(json.Unmarshal([]byte(`1e9223372036854775807`), &d), num.ErrOverflow), "json huge exponent")
+	ExpectedActual(t, true, errors.Is(json.Unmarshal([]byte(`1e-99999999999999999999`), &d), num.ErrInvalidDecimal), "json exponent past int")
+	for _, in := range []string{`1e-19`, `1e19`, `9.3e18`, `"1e3"`, `"12\u0022"`} {
+		ExpectedActual(t, true, json.Unmarshal([]byte(in), &d) != nil, "json error "+in)
+	}
+	ExpectedActual(t, true, errors.Is(json.Unmarshal([]byte(`1e19`), &d), num.ErrOverflow), "json overflow")
+	ExpectedActual(t, true, errors.Is(json.Unmarshal([]byte(`1e-19`), &d), num.ErrInvalidDecimal), "json too precise")
+
+	data, err := json.Marshal(invoice{Total: mustDecimal(t, "19.99")})
+	ExpectedActual(t, nil, err, "marshal json")
+	ExpectedActual(t, `{"Total":"19.99","Tax":"0","Discount":"0"}`, string(data), "json output")
+}
@@ -0,0 +1,132 @@
+package num
+
+import "math"
+
+// AlmostEqual returns true if a and b are within absTol of each other, or within relTol of the larger magnitude
+// of the two. ie, |a-b| <= max(absTol, relTol * max(|a|, |b|))
+//
+// The absolute tolerance handles values near zero, where any relative tolerance becomes tiny, and the relative
+// tolerance handles large values, where even adjacent floats are far apart. A reasonable default for float64 is
+// an absTol around 1e-12 and a relTol around 1e-9, but it depends on how much error your calculations accumulate.
+//
+// NaN is never almost equal to anything, and Inf is only almost equal to an Inf of the same sign.
+func AlmostEqual[F Float](a, b, absTol, relTol F) bool {
+	if a == b {
+		return true // Handles infinities, as well as exact equality being cheap
+	}
+	diff := F(math.Abs(float64(a - b)))
+	if math.IsInf(float64(diff), 0) || math.IsNaN(float64(diff)) {
+		return false
+	}
+	largest := F(math.Max(math.Abs(float64(a)), math.Abs(float64(b))))
+	return diff <= max(absTol, relTol*largest)
+}
+
+// ULPDistance returns the number of representable floats between a and b, known as "units in the last place".
+// Adjacent floats are 1 apart, and -0 and +0 are considered 0 apart. If either is NaN this returns math.MaxUint64.
+//
+// This works in units of F, so ULPDistance(float32(a), float32(b)) counts float32 values.
+func ULPDistance[F Float](a, b F) uint64 {
+	if isNaN(a) || isNaN(b) {
+		return math.MaxUint64
+	}
+	ka, kb := ulpKey(a), ulpKey(b)
+	if ka > kb {
+		return ka - kb
+	}
+	return kb - ka
+}
+
+// AlmostEqualULP returns true if a and b are at most maxULPs representable floats apart.
+// This is a good comparison for values that should differ only by rounding, but not for values near zero,
+// since there are a huge number of floats between 0 and even a tiny number. Use AlmostEqual for those.
+func AlmostEqualULP[F Float](a, b F, maxULPs uint64) bool {
+	return ULPDistance(a, b) <= maxULPs
+}
+
+// NextUp returns the smallest float that is greater than x. NextUp(+Inf) is +Inf, and NextUp(NaN) is NaN.
+func NextUp[F Float](x F) F {
+	if bitSize[F]() == 32 {
+		return F(math.Nextafter32(float32(x), float32(math.Inf(1))))
+	}
+	return F(math.Nextafter(float64(x), math.Inf(1)))
+}
+
+// NextDown returns the largest float that is less than x. NextDown(-Inf) is -Inf, and NextDown(NaN) is NaN.
+func NextDown[F Float](x F) F {
+	if bitSize[F]() == 32 {
+		return F(math.Nextafter32(float32(x), float32(math.Inf(-1))))
+	}
+	return F(math.Nextafter(float64(x), math.Inf(-1)))
+}
+
+// TotalCompare compares a and b using the IEEE 754 totalOrder predicate, returning -1, 0, or +1.
+// Unlike "<", this is a total order that sorts every value, including NaNs and signed zeros:
+//
+//	-NaN < -Inf < negative numbers < -0 < +0 < positive numbers < +Inf < +NaN
+//
+// This makes it safe to use with slices.SortFunc and friends, where NaN would otherwise break the sort.
+func TotalCompare[F Float](a, b F) int {
+	ka, kb := totalKey(a), totalKey(b)
+	switch {
+	case ka < kb:
+		return -1
+	case ka > kb:
+		return 1
+	}
+	return 0
+}
+
+// TotalLess returns true if a sorts before b using TotalCompare.
+func TotalLess[F Float](a, b F) bool {
+	return TotalCompare(a, b) < 0
+}
+
+// IsFinite returns true if x is neither NaN nor an infinity.
+func IsFinite[F Float](x F) bool {
+	// Inf - Inf and NaN - NaN are both NaN, so this is only equal for finite values.
+	return x-x == 0
+}
+
+// IsNaN returns true if x is a NaN. This is the generic version of math.IsNaN.
+func IsNaN[F Float](x F) bool {
+	return isNaN(x)
+}
+
+// IsInf returns true if x is an infinity with the given sign, per math.IsInf.
+// If sign > 0 this checks for +Inf, if sign < 0 this checks for -Inf, and if sign == 0 this checks for either.
+func IsInf[F Float](x F, sign int) bool {
+	return math.IsInf(float64(x), sign)
+}
+
+func isNaN[F Float](x F) bool {
+	return x != x
+}
+
+// floatBits returns the raw bits of x along with the sign bit for its size, so callers don't need to care about the size.
+func floatBits[F Float](x F) (bits, signBit uint64) {
+	if bitSize[F]() == 32 {
+		return uint64(math.Float32bits(float32(x))), 1 << 31
+	}
+	return math.Float64bits(float64(x)), 1 << 63
+}
+
+// totalKey maps x to an unsigned integer with the same ordering as IEEE 754 totalOrder.
+// Positive floats already sort correctly by their bits, so we move them above the negative floats by setting the sign
+// bit. Negative floats sort backwards by their bits, so we flip all of them (which also clears the sign bit).
+func totalKey[F Float](x F) uint64 {
+	b, sign := floatBits(x)
+	if b&sign != 0 {
+		return b ^ (sign<<1 - 1)
+	}
+	return b | sign
+}
+
+// ulpKey is like totalKey, except -0 and +0 both map to the same value. Negative values count down from zero.
+func ulpKey[F Float](x F) uint64 {
+	b, sign := floatBits(x)
+	if b&sign != 0 {
+		return 1<<63 - (b &^ sign)
+	}
+	return 1<<63 + b
+}
@@ -0,0 +1,96 @@
+package num_test
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func TestAlmostEqual(t *testing.T) {
+	tenth := 0.1 // A variable, since constant math is exact and 0.1+0.2 would be 0.3
+	c := []struct {
+		Name   string
+		A, B   float64
+		Abs    float64
+		Rel    float64
+		Expect bool
+	}{
+		{"exact", 1, 1, 0, 0, true},
+		{"classic 0.1+0.2", tenth + 0.2, 0.3, 0, 1e-9, true},
+		{"classic 0.1+0.2 not exact", tenth + 0.2, 0.3, 0, 0, false},
+		{"relative large", 1e20, 1e20 + 1e10, 0, 1e-9, true},
+		{"relative too far", 1e20, 1.001e20, 0, 1e-9, false},
+		{"absolute near zero", 1e-20, -1e-20, 1e-12, 1e-9, true},
+		{"relative fails near zero", 1e-20, -1e-20, 0, 1e-9, false},
+		{"inf", math.Inf(1), math.Inf(1), 0, 0, true},
+		{"inf signs", math.Inf(1), math.Inf(-1), 1, 1, false},
+		{"inf vs max", math.Inf(1), math.MaxFloat64, 1, 1, false},
+		{"nan", math.NaN(), math.NaN(), 1, 1, false},
+	}
+	for _, tc := range c {
+		ExpectedActual(t, tc.Expect, num.AlmostEqual(tc.A, tc.B, tc.Abs, tc.Rel), tc.Name)
+	}
+	ExpectedActual(t, true, num.AlmostEqual(float32(tenth)+0.2, 0.3, 0, 1e-6), "float32")
+}
+
+func TestULP(t *testing.T) {
+	ExpectedActual(t, uint64(0), num.ULPDistance(1.0, 1.0), "same")
+	ExpectedActual(t, uint64(1), num.ULPDistance(1.0, num.NextUp(1.0)), "next up")
+	ExpectedActual(t, uint64(1), num.ULPDistance(1.0, num.NextDown(1.0)), "next down")
+	ExpectedActual(t, uint64(0), num.ULPDistance(0.0, math.Copysign(0, -1)), "signed zeros")
+	ExpectedActual(t, uint64(2), num.ULPDistance(num.NextDown(0.0), num.NextUp(0.0)), "across zero")
+	ExpectedActual(t, uint64(1), num.ULPDistance(math.MaxFloat64, math.Inf(1)), "max to inf")
+	ExpectedActual(t, uint64(math.MaxUint64), num.ULPDistance(math.NaN(), 1), "nan")
+	ExpectedActual(t, uint64(1), num.ULPDistance(float32(1), num.NextUp(float32(1))), "float32 units")
+	ExpectedActual(t, uint64(1), num.ULPDistance(float32(-1), num.NextDown(float32(-1))), "float32 negative")
+
+	a, b := 0.1, 0.2 // Variables, since constant math is exact and 0.1+0.2 would be 0.3
+	ExpectedActual(t, true, num.AlmostEqualULP(a+b, 0.3, 1), "0.1+0.2 within 1 ULP")
+	ExpectedActual(t, false, num.AlmostEqualULP(a+b, 0.3, 0), "0.1+0.2 not exact")
+
+	ExpectedActual(t, float32(math.SmallestNonzeroFloat32), num.NextUp(float32(0)), "float32 next up from zero")
+	ExpectedActual(t, math.Inf(1), num.NextUp(math.Inf(1)), "next up from inf")
+	ExpectedActual(t, -math.MaxFloat64, num.NextUp(math.Inf(-1)), "next up from -inf")
+}
+
+func TestTotalCompare(t *testing.T) {
+	negNaN := math.Copysign(math.NaN(), -1)
+	negZero := math.Copysign(0, -1)
+	sorted := []float64{negNaN, math.Inf(-1), -1, -math.SmallestNonzeroFloat64, negZero, 0, math.SmallestNonzeroFloat64, 1, math.Inf(1), math.NaN()}
+	checkTotalOrder(t, sorted)
+	sorted32 := []float32{float32(negNaN), float32(math.Inf(-1)), -1, -math.SmallestNonzeroFloat32, float32(negZero), 0, math.SmallestNonzeroFloat32, 1, float32(math.Inf(1)), float32(math.NaN())}
+	checkTotalOrder(t, sorted32)
+
+	shuffled := []float64{1, math.NaN(), -1, math.Inf(-1), 0}
+	slices.SortFunc(shuffled, num.TotalCompare)
+	ExpectedActual(t, []float64{math.Inf(-1), -1, 0, 1}, shuffled[:4], "sorted with NaN")
+	ExpectedActual(t, true, num.IsNaN(shuffled[4]), "NaN sorted last")
+	ExpectedActual(t, true, num.TotalLess(negZero, 0), "-0 < +0")
+}
+
+func TestFloatClassification(t *testing.T) {
+	ExpectedActual(t, true, num.IsFinite(1.5), "finite")
+	ExpectedActual(t, true, num.IsFinite(float32(math.MaxFloat32)), "max finite")
+	ExpectedActual(t, false, num.IsFinite(math.Inf(-1)), "inf")
+	ExpectedActual(t, false, num.IsFinite(float32(math.NaN())), "nan")
+	ExpectedActual(t, true, num.IsNaN(float32(math.NaN())), "is nan")
+	ExpectedActual(t, true, num.IsInf(float32(math.Inf(-1)), -1), "is -inf")
+	ExpectedActual(t, false, num.IsInf(math.Inf(-1), 1), "is not +inf")
+}
+
+// checkTotalOrder compares every pair in the sorted slice, expecting each index to sort strictly after the previous.
+func checkTotalOrder[F num.Float](t *testing.T, sorted []F) {
+	t.Helper()
+	for i := range sorted {
+		for j := range sorted {
+			if !ExpectedActual(t, cmp.Compare(i, j), num.TotalCompare(sorted[i], sorted[j]), "total order") {
+				t.Logf("i=%d j=%d", i, j)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package num
+
+import (
+	"math"
+	"strconv"
+)
+
+// Float16 is an IEEE 754 half-precision float: 1 sign bit, 5 exponent bits, and 10 mantissa bits.
+// It's mostly used to save space in graphics buffers, and has a max value of 65504 with about 3 decimal digits of
+// precision. Do math on Float32() and convert back with NewFloat16, since the hardware doesn't support it directly.
+type Float16 uint16
+
+// BFloat16 is a "brain float": the top 16 bits of a float32, with 1 sign bit, 8 exponent bits, and 7 mantissa bits.
+// It has the same range as a float32 but only about 2 decimal digits of precision, and is mostly used in ML tooling.
+type BFloat16 uint16
+
+// floatFormat describes the bit layout of an IEEE 754 binary float.
+type floatFormat struct {
+	mantBits uint
+	expBits  uint
+}
+
+var (
+	float16Format  = floatFormat{mantBits: 10, expBits: 5}
+	bfloat16Format = floatFormat{mantBits: 7, expBits: 8}
+	float32Format  = floatFormat{mantBits: 23, expBits: 8}
+	float64Format  = floatFormat{mantBits: 52, expBits: 11}
+)
+
+func (f floatFormat) bias() int        { return 1<<(f.expBits-1) - 1 }
+func (f floatFormat) expMax() uint64   { return 1<<f.expBits - 1 }
+func (f floatFormat) mantMask() uint64 { return 1<<f.mantBits - 1 }
+
+// NewFloat16 converts f to the nearest Float16, with ties going to even. Values too large become +/-Inf, values too
+// small become +/-0, and NaN stays NaN. Converting a float64 directly avoids the double rounding you'd get by
+// going through a float32 first.
+func NewFloat16[F Float](f F) Float16 {
+	return Float16(narrowFloat(f, float16Format))
+}
+
+// Float32 returns h as a float32. This is exact, as every Float16 can be represented by a float32.
+func (h Float16) Float32() float32 {
+	return math.Float32frombits(uint32(widenFloat(uint64(h), float16Format, float32Format)))
+}
+
+// Float64 returns h as a float64. This is exact, as every Float16 can be represented by a float64.
+func (h Float16) Float64() float64 {
+	return float64(h.Float32())
+}
+
+// String returns the shortest decimal representation that converts back to the same Float16.
+func (h Float16) String() string {
+	return formatNarrowFloat(h.Float32(), func(f float32) bool { return NewFloat16(f) == h })
+}
+
+// NewBFloat16 converts f to the nearest BFloat16, with ties going to even. Values too large become +/-Inf, values too
+// small become +/-0, and NaN stays NaN.
+func NewBFloat16[F Float](f F) BFloat16 {
+	return BFloat16(narrowFloat(f, bfloat16Format))
+}
+
+// Float32 returns b as a float32. This is exact, since a BFloat16 is the top half of a float32.
+func (b BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(b) << 16)
+}
+
+// Float64 returns b as a float64. This is exact, as every BFloat16 can be represented by a float64.
+func (b BFloat16) Float64() float64 {
+	return float64(b.Float32())
+}
+
+// String returns the shortest decimal representation that converts back to the same BFloat16.
+func (b BFloat16) String() string {
+	return formatNarrowFloat(b.Float32(), func(f float32) bool { return NewBFloat16(f) == b })
+}
+
+// NarrowFloat16s converts each of src to a Float16 in dst, returning the number converted.
+// Like copy(), this is the minimum of len(dst) and len(src).
+func NarrowFloat16s[F Float](dst []Float16, src []F) int {
+	n := min(len(dst), len(src))
+	for i := range n {
+		dst[i] = NewFloat16(src[i])
+	}
+	return n
+}
+
+// WidenFloat16s converts each of src to F in dst, returning the number converted.
+// Like copy(), this is the minimum of len(dst) and len(src). Use this to get values that work with gmath.
+func WidenFloat16s[F Float](dst []F, src []Float16) int {
+	n := min(len(dst), len(src))
+	for i := range n {
+		dst[i] = F(src[i].Float32())
+	}
+	return n
+}
+
+// NarrowBFloat16s converts each of src to a BFloat16 in dst, returning the number converted.
+// Like copy(), this is the minimum of len(dst) and len(src).
+func NarrowBFloat16s[F Float](dst []BFloat16, src []F) int {
+	n := min(len(dst), len(src))
+	for i := range n {
+		dst[i] = NewBFloat16(src[i])
+	}
+	return n
+}
+
+// WidenBFloat16s converts each of src to F in dst, returning the number converted.
+// Like copy(), this is the minimum of len(dst) and len(src). Use this to get values that work with gmath.
+func WidenBFloat16s[F Float](dst []F, src []BFloat16) int {
+	n := min(len(dst), len(src))
+	for i := range n {
+		dst[i] = F(src[i].Float32())
+	}
+	return n
+}
+
+// narrowFloat rounds f to the smaller format dst (with round to nearest, ties to even), returning the raw bits.
+func narrowFloat[F Float](f F, dst floatFormat) uint64 {
+	b, _ := floatBits(f)
+	src := float64Format
+	if bitSize[F]() == 32 {
+		src = float32Format
+	}
+
+	sign := (b >> (src.mantBits + src.expBits)) & 1
+	signBit := sign << (dst.mantBits + dst.expBits)
+	exp := (b >> src.mantBits) & src.expMax()
+	mant := b & src.mantMask()
+
+	if exp == src.expMax() {
+		if mant == 0 {
+			return signBit | dst.expMax()<<dst.mantBits // Inf
+		}
+		// Keep the top of the NaN payload, and set the quiet bit so it can't turn into Inf by losing the payload.
+		return signBit | dst.expMax()<<dst.mantBits | 1<<(dst.mantBits-1) | mant>>(src.mantBits-dst.mantBits)
+	}
+	if exp == 0 && mant == 0 {
+		return signBit
+	}
+
+	// Get the unbiased exponent, and the full significand including the implicit leading 1 for normal numbers.
+	e := 1 - src.bias()
+	if exp != 0 {
+		e = int(exp) - src.bias()
+		mant |= 1 << src.mantBits
+	}
+
+	de := e + dst.bias()
+	if de >= int(dst.expMax()) {
+		return signBit | dst.expMax()<<dst.mantBits // Too large, so it's Inf
+	}
+	shift := src.mantBits - dst.mantBits
+	if de < 1 {
+		// This will be a subnormal, so shift the mantissa down further to match the minimum exponent.
+		shift += uint(1 - de)
+		de = 1
+		if shift > src.mantBits+1 {
+			return signBit // Less than half of the smallest subnormal, so it rounds to zero
+		}
+	}
+
+	q := mant >> shift
+	rem := mant & (1<<shift - 1)
+	half := uint64(1) << (shift - 1)
+	if rem > half || (rem == half && q&1 == 1) {
+		q++
+	}
+	// q still contains the implicit 1 for normal numbers, so adding it to (de-1) sets the exponent correctly.
+	// Rounding up can carry into the exponent, which is also correct - including rounding up to Inf.
+	return signBit | (uint64(de-1)<<dst.mantBits + q)
+}
+
+// widenFloat exactly converts the raw bits b in the format src to the larger format dst.
+func widenFloat(b uint64, src, dst floatFormat) uint64 {
+	sign := (b >> (src.mantBits + src.expBits)) & 1
+	signBit := sign << (dst.mantBits + dst.expBits)
+	exp := (b >> src.mantBits) & src.expMax()
+	mant := b & src.mantMask()
+	shift := dst.mantBits - src.mantBits
+
+	switch {
+	case exp == src.expMax():
+		return signBit | dst.expMax()<<dst.mantBits | mant<<shift // Inf or NaN
+	case exp == 0 && mant == 0:
+		return signBit
+	case exp == 0:
+		// Subnormal in src, but normal in dst. Shift until the leading 1 becomes the implicit bit.
+		e := 1 - src.bias()
+		for mant&(1<<src.mantBits) == 0 {
+			mant <<= 1
+			e--
+		}
+		return signBit | uint64(e+dst.bias())<<dst.mantBits | (mant&src.mantMask())<<shift
+	}
+	return signBit | uint64(int(exp)-src.bias()+dst.bias())<<dst.mantBits | mant<<shift
+}
+
+// formatNarrowFloat returns the fewest digits that still round trip through a narrow float, since formatting the
+// float32 directly would print digits that the narrow float doesn't have.
+func formatNarrowFloat(f float32, roundTrips func(float32) bool) string {
+	for prec := 1; prec < 9; prec++ {
+		s := strconv.FormatFloat(float64(f), 'g', prec, 32)
+		if v, err := strconv.ParseFloat(s, 32); err == nil && roundTrips(float32(v)) {
+			// Reformat so we only use an exponent when it's needed, eg "65500" rather than "6.55e+04".
+			return strconv.FormatFloat(v, 'g', -1, 32)
+		}
+	}
+	return strconv.FormatFloat(float64(f), 'g', -1, 32)
+}
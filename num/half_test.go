@@ -0,0 +1,134 @@
+package num_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/gmath"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func TestFloat16KnownValues(t *testing.T) {
+	c := []struct {
+		In       float64
+		Expected num.Float16
+	}{
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.5, 0x3800},
+		{65504, 0x7bff},                // Max finite
+		{65519.99, 0x7bff},             // Just under the halfway point to Inf
+		{65520, 0x7c00},                // Halfway to 65536, which ties to even, so Inf
+		{1e10, 0x7c00},                 // Overflow
+		{math.Ldexp(1, -14), 0x0400},   // Smallest normal
+		{math.Ldexp(1, -24), 0x0001},   // Smallest subnormal
+		{math.Ldexp(1, -25), 0x0000},   // Half of the smallest subnormal, ties to even (zero)
+		{math.Ldexp(1.5, -25), 0x0001}, // More than half rounds up
+		{math.Ldexp(3, -25), 0x0002},   // 1.5 subnormals ties to even (2)
+		{math.Copysign(0, -1), 0x8000},
+		{math.Inf(-1), 0xfc00},
+		{0.1, 0x2e66},
+	}
+	for _, tc := range c {
+		ExpectedActual(t, tc.Expected, num.NewFloat16(tc.In), "float64 "+num.Format(tc.In, -1, ""))
+		ExpectedActual(t, tc.Expected, num.NewFloat16(float32(tc.In)), "float32 "+num.Format(tc.In, -1, ""))
+	}
+	ExpectedActual(t, true, math.IsNaN(num.NewFloat16(math.NaN()).Float64()), "NaN")
+
+	// 1 + 2^-11 + 2^-40 rounds up directly to float16, but rounds down if it goes through a float32 first.
+	doubleRounding := 1 + math.Ldexp(1, -11) + math.Ldexp(1, -40)
+	ExpectedActual(t, num.Float16(0x3c01), num.NewFloat16(doubleRounding), "no double rounding")
+
+	ExpectedActual(t, "0.1", num.NewFloat16(0.1).String(), "string shortest")
+	ExpectedActual(t, "65500", num.Float16(0x7bff).String(), "string max")
+}
+
+func TestBFloat16KnownValues(t *testing.T) {
+	ExpectedActual(t, num.BFloat16(0x3f80), num.NewBFloat16(1.0), "one")
+	ExpectedActual(t, num.BFloat16(0x7f80), num.NewBFloat16(math.MaxFloat32), "max float32 rounds to Inf")
+	ExpectedActual(t, num.BFloat16(0x7f7f), num.NewBFloat16(3.3895e38), "max bfloat16")
+	ExpectedActual(t, num.BFloat16(0x7f80), num.NewBFloat16(1e39), "float64 overflow")
+	ExpectedActual(t, num.BFloat16(0x0001), num.NewBFloat16(math.Ldexp(1, -133)), "smallest subnormal")
+	ExpectedActual(t, num.BFloat16(0x0000), num.NewBFloat16(1e-50), "float64 underflow")
+	ExpectedActual(t, num.BFloat16(0x3dcd), num.NewBFloat16(0.1), "0.1")
+	ExpectedActual(t, "0.1", num.NewBFloat16(0.1).String(), "string shortest")
+	ExpectedActual(t, true, math.IsNaN(num.NewBFloat16(float32(math.NaN())).Float64()), "NaN")
+}
+
+// Every one of the 65536 values must survive a round trip through both float32 and float64.
+func TestHalfRoundTrips(t *testing.T) {
+	for i := range 1 << 16 {
+		h := num.Float16(i)
+		b := num.BFloat16(i)
+		if num.IsNaN(h.Float32()) {
+			if !ExpectedActual(t, true, num.IsNaN(num.NewFloat16(h.Float64()).Float32()), "float16 NaN") {
+				return
+			}
+		} else if !ExpectedActual(t, h, num.NewFloat16(h.Float32()), "float16 via float32") ||
+			!ExpectedActual(t, h, num.NewFloat16(h.Float64()), "float16 via float64") {
+			t.Logf("bits=%#04x", i)
+			return
+		}
+
+		if num.IsNaN(b.Float32()) {
+			if !ExpectedActual(t, true, num.IsNaN(num.NewBFloat16(b.Float64()).Float32()), "bfloat16 NaN") {
+				return
+			}
+		} else if !ExpectedActual(t, b, num.NewBFloat16(b.Float32()), "bfloat16 via float32") ||
+			!ExpectedActual(t, b, num.NewBFloat16(b.Float64()), "bfloat16 via float64") {
+			t.Logf("bits=%#04x", i)
+			return
+		}
+	}
+}
+
+// For every pair of adjacent finite positive values, check that the midpoint ties to even and that anything on either
+// side of the midpoint rounds to the nearer value. Negative values are the same with the sign bit set.
+func TestHalfRoundsToNearestEven(t *testing.T) {
+	checkMidpoints(t, "float16", 0x7c00, func(i int) float64 { return num.Float16(i).Float64() },
+		func(f float64) int { return int(num.NewFloat16(f)) })
+	checkMidpoints(t, "bfloat16", 0x7f80, func(i int) float64 { return num.BFloat16(i).Float64() },
+		func(f float64) int { return int(num.NewBFloat16(f)) })
+}
+
+func checkMidpoints(t *testing.T, name string, inf int, widen func(int) float64, narrow func(float64) int) {
+	t.Helper()
+	// The last finite value is skipped, since the next value is Inf and there is no midpoint. The known value tests
+	// cover rounding up to Inf instead.
+	for i := 0; i < inf-1; i++ {
+		lo, hi := widen(i), widen(i+1)
+		mid := lo + (hi-lo)/2 // Exact, since these have far fewer bits than a float64
+		even := i
+		if i%2 == 1 {
+			even = i + 1
+		}
+		if !ExpectedActual(t, even, narrow(mid), name+" midpoint") ||
+			!ExpectedActual(t, i, narrow(num.NextDown(mid)), name+" below midpoint") ||
+			!ExpectedActual(t, i+1, narrow(num.NextUp(mid)), name+" above midpoint") ||
+			!ExpectedActual(t, i|0x8000, narrow(-num.NextDown(mid)), name+" negative") {
+			t.Logf("bits=%#04x", i)
+			return
+		}
+	}
+}
+
+func TestHalfSlices(t *testing.T) {
+	src := []float32{1, 0.5, -2, 65504}
+	halves := make([]num.Float16, 3)
+	ExpectedActual(t, 3, num.NarrowFloat16s(halves, src), "narrow count")
+	ExpectedActual(t, []num.Float16{0x3c00, 0x3800, 0xc000}, halves, "narrowed")
+
+	wide := make([]float64, 5)
+	ExpectedActual(t, 3, num.WidenFloat16s(wide, halves), "widen count")
+	ExpectedActual(t, []float64{1, 0.5, -2, 0, 0}, wide, "widened")
+
+	brains := make([]num.BFloat16, len(src))
+	ExpectedActual(t, 4, num.NarrowBFloat16s(brains, src), "bfloat16 narrow count")
+	back := make([]float32, len(src))
+	ExpectedActual(t, 4, num.WidenBFloat16s(back, brains), "bfloat16 widen count")
+	ExpectedActual(t, []float32{1, 0.5, -2, 65536}, back, "bfloat16 widened") // 65504 needs more than 8 significant bits
+
+	// Widened values work directly with gmath.
+	ExpectedActual(t, float32(0.75), gmath.Lerp(num.Float16(0x3800).Float32(), num.Float16(0x3c00).Float32(), float32(0.5)), "lerp")
+}
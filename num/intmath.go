@@ -0,0 +1,301 @@
+package num
+
+import (
+	"math"
+	"math/bits"
+)
+
+// GCD returns the greatest common divisor of a and b, which is always non-negative. GCD(0, 0) is 0.
+// For signed types this overflows when the answer is 2^(bits-1), eg GCD(MinOf[int8](), 0), since that doesn't fit.
+func GCD[N Integer](a, b N) N {
+	x, _ := magnitude(a)
+	y, _ := magnitude(b)
+	return N(gcd64(x, y))
+}
+
+// LCM returns the least common multiple of a and b, which is always non-negative. If either is 0 the result is 0.
+// Like normal Go arithmetic this wraps on overflow - use MulChecked on the result of GCD if that's a concern.
+func LCM[N Integer](a, b N) N {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	x, _ := magnitude(a)
+	y, _ := magnitude(b)
+	return N(x / gcd64(x, y) * y)
+}
+
+// ExtGCD runs the extended Euclidean algorithm, returning g = GCD(a, b) along with x and y such that a*x + b*y = g.
+func ExtGCD[N Signed](a, b N) (g, x, y N) {
+	oldR, r := a, b
+	oldX, x := N(1), N(0)
+	oldY, y := N(0), N(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// ModPow returns (base^exp) mod m, in the range [0, m). This never overflows, even for large 64-bit moduli.
+// Negative bases are fine, but it panics if exp is negative or m is not positive.
+func ModPow[N Integer](base, exp, m N) N {
+	if exp < 0 || m <= 0 {
+		panic("num: ModPow requires exp >= 0 and m > 0")
+	}
+	mod := uint64(m)
+	b := uint64(FloorMod(base, m))
+	result := 1 % mod
+	for e := uint64(exp); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, b, mod)
+		}
+		b = mulMod(b, b, mod)
+	}
+	return N(result)
+}
+
+// ModInverse returns x in the range [0, m) such that (a*x) mod m is 1.
+// This returns false if there is no inverse, which is when a and m are not coprime. It panics if m is not positive.
+func ModInverse[N Integer](a, m N) (N, bool) {
+	if m <= 0 {
+		panic("num: ModInverse requires m > 0")
+	}
+	inv, ok := modInverse64(uint64(FloorMod(a, m)), uint64(m))
+	return N(inv), ok
+}
+
+// CRT solves the system of congruences x ≡ residues[i] (mod moduli[i]) using the Chinese Remainder Theorem,
+// returning x in the range [0, m) where m is the LCM of all the moduli. The moduli don't need to be coprime.
+//
+// This returns false if the system has no solution, if the slices are different lengths, if a modulus isn't positive,
+// or if m would overflow N.
+func CRT[N Integer](residues, moduli []N) (x, m N, ok bool) {
+	if len(residues) != len(moduli) {
+		return 0, 0, false
+	}
+
+	x64, m64 := uint64(0), uint64(1)
+	for i, mod := range moduli {
+		if mod <= 0 {
+			return 0, 0, false
+		}
+		mi := uint64(mod)
+		ai := uint64(FloorMod(residues[i], mod))
+
+		g := gcd64(m64, mi)
+		// We need x + m64*k ≡ ai (mod mi). Since g divides mi, the difference mod mi keeps its remainder mod g.
+		diff := subMod(ai%mi, x64%mi, mi)
+		if diff%g != 0 {
+			return 0, 0, false
+		}
+		lcm, noOverflow := MulChecked(m64/g, mi)
+		if !noOverflow {
+			return 0, 0, false
+		}
+		step := mi / g
+		inv, _ := modInverse64((m64/g)%step, step) // Always exists, as m64/g and mi/g are coprime
+		k := mulMod(diff/g, inv, step)
+		// k < mi/g and x64 < m64, so x64 + m64*k < lcm and this can't overflow.
+		x64 += m64 * k
+		m64 = lcm
+	}
+
+	x, err := Convert[N](x64)
+	if err != nil {
+		return 0, 0, false
+	}
+	m, err = Convert[N](m64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return x, m, true
+}
+
+// ISqrt returns the floor of the square root of n. It panics if n is negative.
+func ISqrt[N Integer](n N) N {
+	if n < 0 {
+		panic("num: ISqrt of negative number")
+	}
+	u := uint64(n)
+	// The float estimate is within 1 of the answer, but can be off in either direction for large values.
+	r := uint64(math.Sqrt(float64(u)))
+	for r > 0 && (r > math.MaxUint32 || r*r > u) {
+		r--
+	}
+	for r < math.MaxUint32 && (r+1)*(r+1) <= u {
+		r++
+	}
+	return N(r)
+}
+
+// Log2 returns the floor of the base 2 logarithm of n, or -1 if n <= 0.
+func Log2[N Integer](n N) int {
+	if n <= 0 {
+		return -1
+	}
+	return bits.Len64(uint64(n)) - 1
+}
+
+// Log10 returns the floor of the base 10 logarithm of n, or -1 if n <= 0.
+// Add 1 to this to get the number of decimal digits in a positive number.
+func Log10[N Integer](n N) int {
+	if n <= 0 {
+		return -1
+	}
+	u := uint64(n)
+	log := 0
+	for u >= 10 {
+		u /= 10
+		log++
+	}
+	return log
+}
+
+// millerRabinBases are sufficient to deterministically test every number below 2^64.
+// See https://miller-rabin.appspot.com/ and OEIS A014233.
+var millerRabinBases = [...]uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// IsPrime returns true if n is prime. This is a deterministic Miller-Rabin test, so it is exact for all 64-bit values.
+func IsPrime[N Integer](n N) bool {
+	if n < 2 {
+		return false
+	}
+	u := uint64(n)
+	for _, p := range millerRabinBases {
+		if u%p == 0 {
+			return u == p
+		}
+	}
+
+	// Write u-1 as d * 2^s with d odd.
+	d := u - 1
+	s := bits.TrailingZeros64(d)
+	d >>= s
+
+	for _, a := range millerRabinBases {
+		x := ModPow(a, d, u)
+		if x == 1 || x == u-1 {
+			continue
+		}
+		composite := true
+		for range s - 1 {
+			x = mulMod(x, x, u)
+			if x == u-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// PrimeSieve returns a table where table[i] is true if i is prime, for all 0 <= i <= n.
+// This is a sieve of Eratosthenes, so it's the fastest way to test many small numbers.
+func PrimeSieve(n int) []bool {
+	if n < 0 {
+		return nil
+	}
+	table := make([]bool, n+1)
+	for i := 2; i <= n; i++ {
+		table[i] = true
+	}
+	for i := 2; i*i <= n; i++ {
+		if table[i] {
+			for j := i * i; j <= n; j += i {
+				table[j] = false
+			}
+		}
+	}
+	return table
+}
+
+// PrimesUpTo returns all primes p where p <= n, in ascending order.
+func PrimesUpTo[N Integer](n N) []N {
+	if n < 2 {
+		return nil
+	}
+	var primes []N
+	for i, isPrime := range PrimeSieve(int(n)) {
+		if isPrime {
+			primes = append(primes, N(i))
+		}
+	}
+	return primes
+}
+
+// FloorDiv returns a/b rounded towards negative infinity, rather than towards zero like Go's "/".
+// eg, FloorDiv(-7, 2) is -4, where -7/2 is -3.
+func FloorDiv[N Integer](a, b N) N {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// CeilDiv returns a/b rounded towards positive infinity. eg, CeilDiv(7, 2) is 4, and CeilDiv(-7, 2) is -3.
+func CeilDiv[N Integer](a, b N) N {
+	q := a / b
+	if (a%b != 0) && ((a < 0) == (b < 0)) {
+		q++
+	}
+	return q
+}
+
+// FloorMod returns the remainder of FloorDiv(a, b), which always has the same sign as b (like Python's "%").
+// eg, FloorMod(-1, 5) is 4, where -1%5 is -1. This is what you want for wrapping indexes around a grid.
+func FloorMod[N Integer](a, b N) N {
+	r := a % b
+	if r != 0 && ((r < 0) != (b < 0)) {
+		r += b
+	}
+	return r
+}
+
+func gcd64(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// mulMod returns (a*b) mod m without overflowing.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// subMod returns (a-b) mod m, where a and b are already in the range [0, m).
+func subMod(a, b, m uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return m - (b - a)
+}
+
+// modInverse64 is the extended Euclidean algorithm, but keeping the coefficients mod m so that everything stays
+// unsigned and works for the full uint64 range.
+func modInverse64(a, m uint64) (uint64, bool) {
+	if m == 1 {
+		return 0, true
+	}
+	oldR, r := m, a
+	oldT, t := uint64(0), uint64(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldT, t = t, subMod(oldT, mulMod(q%m, t, m), m)
+	}
+	if oldR != 1 {
+		return 0, false
+	}
+	return oldT, true
+}
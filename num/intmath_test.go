@@ -0,0 +1,140 @@
+package num_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func TestGCDLCM(t *testing.T) {
+	ExpectedActual(t, 6, num.GCD(12, 18), "gcd")
+	ExpectedActual(t, 6, num.GCD(-12, 18), "gcd negative")
+	ExpectedActual(t, 5, num.GCD(0, -5), "gcd zero")
+	ExpectedActual(t, 0, num.GCD(0, 0), "gcd zeros")
+	ExpectedActual(t, uint64(1<<63), num.GCD[uint64](1<<63, 0), "gcd large unsigned")
+
+	ExpectedActual(t, 36, num.LCM(12, 18), "lcm")
+	ExpectedActual(t, 36, num.LCM(-12, 18), "lcm negative")
+	ExpectedActual(t, 0, num.LCM(0, 18), "lcm zero")
+}
+
+func TestExtGCD(t *testing.T) {
+	for a := int16(-50); a <= 50; a++ {
+		for b := int16(-50); b <= 50; b++ {
+			g, x, y := num.ExtGCD(a, b)
+			if !ExpectedActual(t, num.GCD(a, b), g, "ext gcd") || !ExpectedActual(t, g, a*x+b*y, "bezout identity") {
+				t.Logf("a=%d b=%d", a, b)
+				return
+			}
+		}
+	}
+}
+
+func TestModPowInverse(t *testing.T) {
+	ExpectedActual(t, 445, num.ModPow(4, 13, 497), "modpow")
+	ExpectedActual(t, 0, num.ModPow(4, 13, 1), "modpow mod 1")
+	ExpectedActual(t, 1, num.ModPow(4, 0, 497), "modpow exp 0")
+	ExpectedActual(t, 4, num.ModPow(-1, 1, 5), "modpow negative base")
+
+	// Compare against math/big for a modulus where a*b overflows a uint64.
+	const m = math.MaxUint64 - 58 // The largest 64-bit prime
+	expected := new(big.Int).Exp(big.NewInt(3), new(big.Int).SetUint64(m-2), new(big.Int).SetUint64(m))
+	ExpectedActual(t, expected.Uint64(), num.ModPow[uint64](3, m-2, m), "modpow large")
+
+	inv, ok := num.ModInverse(3, 11)
+	ExpectedActual(t, true, ok, "inverse exists")
+	ExpectedActual(t, 4, inv, "inverse")
+	inv, ok = num.ModInverse(-3, 11)
+	ExpectedActual(t, true, ok, "negative inverse exists")
+	ExpectedActual(t, 7, inv, "negative inverse")
+	_, ok = num.ModInverse(6, 9)
+	ExpectedActual(t, false, ok, "no inverse")
+
+	inv64, ok := num.ModInverse[uint64](3, m)
+	ExpectedActual(t, true, ok, "large inverse exists")
+	ExpectedActual(t, num.ModPow[uint64](3, m-2, m), inv64, "large inverse matches Fermat")
+}
+
+func TestCRT(t *testing.T) {
+	x, m, ok := num.CRT([]int{2, 3, 2}, []int{3, 5, 7})
+	ExpectedActual(t, true, ok, "coprime ok")
+	ExpectedActual(t, 23, x, "coprime x")
+	ExpectedActual(t, 105, m, "coprime m")
+
+	// Non-coprime moduli that are consistent: x ≡ 3 (mod 4), x ≡ 5 (mod 6)  ->  x ≡ 11 (mod 12)
+	x, m, ok = num.CRT([]int{3, -1}, []int{4, 6})
+	ExpectedActual(t, true, ok, "non-coprime ok")
+	ExpectedActual(t, 11, x, "non-coprime x")
+	ExpectedActual(t, 12, m, "non-coprime m")
+
+	_, _, ok = num.CRT([]int{1, 2}, []int{4, 6})
+	ExpectedActual(t, false, ok, "inconsistent")
+	_, _, ok = num.CRT([]int8{1, 2}, []int8{13, 11})
+	ExpectedActual(t, false, ok, "overflow")
+	_, _, ok = num.CRT([]int{1}, []int{0})
+	ExpectedActual(t, false, ok, "zero modulus")
+}
+
+func TestISqrtLog(t *testing.T) {
+	for n := 0; n < 10000; n++ {
+		r := num.ISqrt(n)
+		if !ExpectedActual(t, true, r*r <= n && (r+1)*(r+1) > n, "isqrt") {
+			t.Logf("n=%d r=%d", n, r)
+			return
+		}
+	}
+	ExpectedActual(t, uint64(math.MaxUint32), num.ISqrt[uint64](math.MaxUint64), "isqrt max uint64")
+	ExpectedActual(t, int64(3037000499), num.ISqrt[int64](math.MaxInt64), "isqrt max int64")
+
+	ExpectedActual(t, -1, num.Log2(0), "log2 zero")
+	ExpectedActual(t, 0, num.Log2(1), "log2 one")
+	ExpectedActual(t, 9, num.Log2(1023), "log2 1023")
+	ExpectedActual(t, 10, num.Log2(1024), "log2 1024")
+	ExpectedActual(t, 63, num.Log2[uint64](math.MaxUint64), "log2 max")
+	ExpectedActual(t, -1, num.Log10(-5), "log10 negative")
+	ExpectedActual(t, 2, num.Log10(999), "log10 999")
+	ExpectedActual(t, 3, num.Log10(1000), "log10 1000")
+	ExpectedActual(t, 19, num.Log10[uint64](math.MaxUint64), "log10 max")
+}
+
+func TestPrimes(t *testing.T) {
+	ExpectedActual(t, []int{2, 3, 5, 7, 11, 13, 17, 19}, num.PrimesUpTo(20), "primes up to 20")
+	ExpectedActual(t, []int(nil), num.PrimesUpTo(1), "primes up to 1")
+
+	sieve := num.PrimeSieve(100000)
+	for n, expected := range sieve {
+		if !ExpectedActual(t, expected, num.IsPrime(n), "IsPrime matches sieve") {
+			t.Logf("n=%d", n)
+			return
+		}
+	}
+
+	ExpectedActual(t, false, num.IsPrime(-7), "negative")
+	ExpectedActual(t, true, num.IsPrime[uint64](math.MaxUint64-58), "largest 64-bit prime")
+	ExpectedActual(t, false, num.IsPrime[uint64](math.MaxUint64), "max uint64")
+	// Strong pseudoprime to every prime base up to 23 - checks that we test enough bases.
+	ExpectedActual(t, false, num.IsPrime[uint64](3825123056546413051), "strong pseudoprime")
+	ExpectedActual(t, true, num.IsPrime(int32(math.MaxInt32)), "Mersenne prime")
+}
+
+func TestFloorCeilDivMod(t *testing.T) {
+	for a := -20; a <= 20; a++ {
+		for b := -5; b <= 5; b++ {
+			if b == 0 {
+				continue
+			}
+			q := float64(a) / float64(b)
+			if !ExpectedActual(t, int(math.Floor(q)), num.FloorDiv(a, b), "floor div") ||
+				!ExpectedActual(t, int(math.Ceil(q)), num.CeilDiv(a, b), "ceil div") ||
+				!ExpectedActual(t, a-b*num.FloorDiv(a, b), num.FloorMod(a, b), "floor mod") {
+				t.Logf("a=%d b=%d", a, b)
+				return
+			}
+		}
+	}
+	ExpectedActual(t, 4, num.FloorMod(-1, 5), "wrap index")
+	ExpectedActual(t, uint8(4), num.CeilDiv[uint8](7, 2), "unsigned ceil")
+}
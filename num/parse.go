@@ -0,0 +1,161 @@
+package num
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Parse parses s as the numeric type N, choosing the strconv function and bit size that match N. This accepts Go's
+// number syntax, so underscores ("1_000_000") and base prefixes ("0xff", "0o17", "0b101") work as expected.
+//
+//	workers, err := num.Parse[uint8](os.Getenv("WORKERS"))
+//
+// Integer types also accept scientific notation, as long as the value is a whole number (eg, "1e6" or "1.5e3").
+// All errors are *strconv.NumError with a Func of "Parse". Values outside of N's range give an Err of strconv.ErrRange, and values with a
+// fractional part when N is an integer give ErrTruncated, including ones too small for a float64 like "1e-9999999".
+func Parse[N Real](s string) (N, error) {
+	if IsFloat[N]() {
+		f, err := strconv.ParseFloat(s, int(bitSize[N]()))
+		return N(f), asParseError(err)
+	}
+
+	var v N
+	var err error
+	if isSigned[N]() {
+		var i int64
+		i, err = strconv.ParseInt(s, 0, int(bitSize[N]()))
+		v = N(i)
+	} else {
+		var u uint64
+		u, err = strconv.ParseUint(s, 0, int(bitSize[N]()))
+		v = N(u)
+	}
+	if err == nil || !isSyntaxError(err) {
+		return v, asParseError(err)
+	}
+	return parseIntegerFloat[N](s, asParseError(err))
+}
+
+// parseIntegerFloat handles the cases that ParseInt/ParseUint consider syntax errors, but are reasonable to want as
+// an integer - scientific notation, and negative numbers for unsigned types (which are then a range error).
+// origErr is returned if s isn't any sort of number.
+func parseIntegerFloat[N Real](s string, origErr error) (N, error) {
+	// Going through ParseFloat first limits how large of an exponent we'll hand to big.Rat. The float value
+	// itself isn't used, since it could have been rounded.
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil && !isSyntaxError(err) {
+		return 0, parseError(s, strconv.ErrRange) // Too large of an exponent
+	}
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return 0, origErr
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		// big.Rat refuses exponents past about 10 million, but ParseFloat has already said s is a number, and it can
+		// only be that large when it's tiny (eg "1e-9999999") since huge values are a range error above.
+		mantissa, _, _ := strings.Cut(strings.ToLower(s), "e")
+		if f == 0 && strings.ContainsAny(mantissa, "123456789") {
+			return 0, parseError(s, ErrTruncated)
+		}
+		if f == 0 {
+			return 0, nil
+		}
+		return 0, origErr
+	}
+	if !r.IsInt() {
+		return 0, parseError(s, ErrTruncated)
+	}
+
+	n := r.Num()
+	var v N
+	switch {
+	case n.IsInt64():
+		v, err = Convert[N](n.Int64())
+	case n.IsUint64():
+		v, err = Convert[N](n.Uint64())
+	default:
+		err = ErrOverflow
+	}
+	if err != nil {
+		return 0, parseError(s, strconv.ErrRange)
+	}
+	return v, nil
+}
+
+func isSyntaxError(err error) bool {
+	return errors.Is(err, strconv.ErrSyntax)
+}
+
+func parseError(s string, err error) error {
+	return &strconv.NumError{Func: "Parse", Num: s, Err: err}
+}
+
+// asParseError renames strconv's Func (eg "ParseFloat") to "Parse", so callers see one name for every N.
+func asParseError(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return parseError(numErr.Num, numErr.Err)
+	}
+	return err
+}
+
+// Format returns v as a string with exactly "precision" digits after the decimal point, and with sep inserted between
+// each group of thousands in the integer part. Pass a precision of -1 to use the fewest digits that represent v
+// exactly, and an empty sep for no grouping.
+//
+//	num.Format(1234567.891, 2, ",") // "1,234,567.89"
+//	num.Format(int64(-1234), -1, "_") // "-1_234"
+//
+// Integers are always formatted exactly, so a large int64 won't lose precision by going through a float.
+func Format[N Real](v N, precision int, sep string) string {
+	var s string
+	switch {
+	case IsFloat[N]():
+		s = strconv.FormatFloat(float64(v), 'f', precision, int(bitSize[N]()))
+	case isSigned[N]():
+		s = strconv.FormatInt(int64(v), 10)
+	default:
+		s = strconv.FormatUint(uint64(v), 10)
+	}
+
+	if !IsFloat[N]() && precision > 0 {
+		s += "." + strings.Repeat("0", precision)
+	}
+	if sep == "" {
+		return s
+	}
+	return groupThousands(s, sep)
+}
+
+// groupThousands inserts sep between groups of 3 digits in the integer part of the formatted number s.
+func groupThousands(s, sep string) string {
+	sign := ""
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	// NaN and Inf have nothing to group.
+	if len(intPart) <= 3 || intPart[0] < '0' || intPart[0] > '9' {
+		return sign + s
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	first := len(intPart) % 3
+	if first == 0 {
+		first = 3
+	}
+	b.WriteString(intPart[:first])
+	for i := first; i < len(intPart); i += 3 {
+		b.WriteString(sep)
+		b.WriteString(intPart[i : i+3])
+	}
+	b.WriteString(frac)
+	return b.String()
+}
@@ -0,0 +1,123 @@
+package num_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func TestParseIntegers(t *testing.T) {
+	c := []struct {
+		In       string
+		Expected int64
+	}{
+		{"42", 42},
+		{"-42", -42},
+		{"1_000_000", 1000000},
+		{"0xff", 255},
+		{"0o17", 15},
+		{"0b101", 5},
+		{"1e6", 1000000},
+		{"1.5e3", 1500},
+		{"-9.223372036854775808e18", math.MinInt64},
+		{"0e-99999999999999999999", 0},
+	}
+	for _, tc := range c {
+		v, err := num.Parse[int64](tc.In)
+		ExpectedActual(t, nil, err, tc.In+" error")
+		ExpectedActual(t, tc.Expected, v, tc.In)
+	}
+
+	u8, err := num.Parse[uint8]("255")
+	ExpectedActual(t, nil, err, "uint8 max error")
+	ExpectedActual(t, uint8(255), u8, "uint8 max")
+	u64, err := num.Parse[uint64]("18446744073709551615")
+	ExpectedActual(t, nil, err, "uint64 max error")
+	ExpectedActual(t, uint64(math.MaxUint64), u64, "uint64 max")
+}
+
+func TestParseIntegerErrors(t *testing.T) {
+	c := []struct {
+		In       string
+		Expected error
+	}{
+		{"256", strconv.ErrRange},
+		{"-1", strconv.ErrRange},
+		{"3e2", strconv.ErrRange},
+		{"1e400", strconv.ErrRange},
+		{"1e-400", num.ErrTruncated},
+		{"1e-9999999", num.ErrTruncated},
+		{"5e-99999999999999999999", num.ErrTruncated},
+		{"Inf", strconv.ErrSyntax},
+		{"1.5", num.ErrTruncated},
+		{"2.5e-1", num.ErrTruncated},
+		{"abc", strconv.ErrSyntax},
+		{"", strconv.ErrSyntax},
+		{"NaN", strconv.ErrSyntax},
+		{"1,000", strconv.ErrSyntax},
+	}
+	for _, tc := range c {
+		_, err := num.Parse[uint8](tc.In)
+		expectConvertErr(t, tc.Expected, err, tc.In)
+		_, isNumErr := err.(*strconv.NumError)
+		ExpectedActual(t, true, isNumErr, tc.In+" is NumError")
+	}
+}
+
+func TestParseFloats(t *testing.T) {
+	f, err := num.Parse[float64]("1_000.5")
+	ExpectedActual(t, nil, err, "underscores error")
+	ExpectedActual(t, 1000.5, f, "underscores")
+
+	f, err = num.Parse[float64]("0x1p-2")
+	ExpectedActual(t, nil, err, "hex float error")
+	ExpectedActual(t, 0.25, f, "hex float")
+
+	f32, err := num.Parse[float32]("0.1")
+	ExpectedActual(t, nil, err, "float32 error")
+	ExpectedActual(t, float32(0.1), f32, "float32")
+
+	_, err = num.Parse[float32]("1e39")
+	expectConvertErr(t, strconv.ErrRange, err, "float32 range")
+	_, err = num.Parse[float64]("1e39")
+	ExpectedActual(t, nil, err, "float64 in range")
+	f, err = num.Parse[float64]("1e-9999999")
+	ExpectedActual(t, nil, err, "float64 underflow error")
+	ExpectedActual(t, 0.0, f, "float64 underflow")
+}
+
+func TestParseErrorFunc(t *testing.T) {
+	_, floatErr := num.Parse[float64]("abc")
+	_, floatRangeErr := num.Parse[float32]("1e39")
+	_, intErr := num.Parse[int]("abc")
+	_, uintErr := num.Parse[uint16]("70000")
+	_, truncErr := num.Parse[int]("1.5")
+	for _, err := range []error{floatErr, floatRangeErr, intErr, uintErr, truncErr} {
+		var numErr *strconv.NumError
+		ExpectedActual(t, true, errors.As(err, &numErr), "NumError")
+		ExpectedActual(t, "Parse", numErr.Func, err.Error())
+	}
+	ExpectedActual(t, true, errors.Is(floatErr, strconv.ErrSyntax), "float syntax kept")
+	ExpectedActual(t, true, errors.Is(uintErr, strconv.ErrRange), "uint range kept")
+}
+
+func TestFormat(t *testing.T) {
+	ExpectedActual(t, "1,234,567.89", num.Format(1234567.891, 2, ","), "float grouped")
+	ExpectedActual(t, "-1_234", num.Format(int64(-1234), -1, "_"), "int grouped")
+	ExpectedActual(t, "123", num.Format(123, -1, ","), "short int")
+	ExpectedActual(t, "123,456", num.Format(123456, -1, ","), "exact group of 3")
+	ExpectedActual(t, "1,000.00", num.Format(uint16(1000), 2, ","), "int with precision")
+	ExpectedActual(t, "0.1", num.Format(float32(0.1), -1, ""), "float32 shortest")
+	ExpectedActual(t, "9 223 372 036 854 775 807", num.Format(int64(math.MaxInt64), 0, " "), "int64 max exact")
+	ExpectedActual(t, "-Inf", num.Format(math.Inf(-1), 2, ","), "Inf")
+	ExpectedActual(t, "NaN", num.Format(math.NaN(), 2, ","), "NaN")
+
+	// Formatting should round trip through Parse when there's no separator.
+	v, err := num.Parse[float64](num.Format(0.1+0.2, -1, ""))
+	ExpectedActual(t, nil, err, "round trip error")
+	ExpectedActual(t, 0.1+0.2, v, "round trip")
+}
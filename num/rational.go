@@ -0,0 +1,225 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+var ErrInvalidRational = errors.New("invalid rational number")
+
+// Rational is an exact fraction with a numerator and denominator of type I, always kept in lowest terms with a positive
+// denominator. The zero value is 0/1, and is ready to use.
+//
+// Arithmetic reports overflow rather than wrapping, the same as AddChecked and friends. When that happens you can
+// switch over to math/big using Big(), and come back with RationalFromBig() once the value is small enough again.
+//
+//	frameTime := num.NewRational[int64](1001, 30000) // NTSC 29.97fps
+//	total, ok := frameTime.Mul(num.NewRational[int64](frames, 1))
+type Rational[I Signed] struct {
+	num I
+	den I // Stored as den-1, so the zero value is 0/1
+}
+
+// NewRational returns n/d reduced to lowest terms.
+// It panics if d is 0, or if the result can't be represented (only possible when d is MinOf[I]()), like big.NewRat.
+func NewRational[I Signed](n, d I) Rational[I] {
+	if d == 0 {
+		panic("num: NewRational with zero denominator")
+	}
+	r, ok := reduceRational(n, d)
+	if !ok {
+		panic("num: NewRational result overflows")
+	}
+	return r
+}
+
+// RationalFromBig converts r to a Rational[I], returning ErrOverflow if its numerator or denominator doesn't fit in I.
+func RationalFromBig[I Signed](r *big.Rat) (Rational[I], error) {
+	if !r.Num().IsInt64() || !r.Denom().IsInt64() {
+		return Rational[I]{}, rationalError[I](ErrOverflow, r.RatString())
+	}
+	n, err := Convert[I](r.Num().Int64())
+	if err != nil {
+		return Rational[I]{}, rationalError[I](ErrOverflow, r.RatString())
+	}
+	d, err := Convert[I](r.Denom().Int64())
+	if err != nil {
+		return Rational[I]{}, rationalError[I](ErrOverflow, r.RatString())
+	}
+	// big.Rat is always normalized, so there's no need to reduce again.
+	return Rational[I]{num: n, den: d - 1}, nil
+}
+
+// RationalFromFloat returns the exact value of f as a Rational[I]. Note that most decimal fractions aren't exact in
+// binary, so 0.1 is 3602879701896397/36028797018963968 and will overflow anything smaller than an int64.
+// This returns ErrNotFinite for NaN or Inf, and ErrOverflow if the value doesn't fit.
+func RationalFromFloat[I Signed, F Float](f F) (Rational[I], error) {
+	if !IsFinite(f) {
+		return Rational[I]{}, rationalError[I](ErrNotFinite, f)
+	}
+	return RationalFromBig[I](new(big.Rat).SetFloat64(float64(f)))
+}
+
+// ParseRational parses a fraction such as "3/4" or "-3/4", an integer such as "5", or an exact decimal such as "0.75".
+// This returns an error wrapping ErrInvalidRational if s isn't valid, or ErrOverflow if the value doesn't fit.
+func ParseRational[I Signed](s string) (Rational[I], error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Rational[I]{}, &strconv.NumError{Func: "ParseRational", Num: s, Err: ErrInvalidRational}
+	}
+	return RationalFromBig[I](r)
+}
+
+// Num returns the numerator, which has the same sign as the rational number.
+func (r Rational[I]) Num() I {
+	return r.num
+}
+
+// Denom returns the denominator, which is always positive.
+func (r Rational[I]) Denom() I {
+	return r.den + 1
+}
+
+// Add returns r+o, and false if the result doesn't fit in a Rational[I].
+func (r Rational[I]) Add(o Rational[I]) (Rational[I], bool) {
+	// a/b + c/d = (a*(d/g) + c*(b/g)) / (b/g * d), where g = GCD(b, d). Dividing out g first avoids most overflows.
+	b, d := r.Denom(), o.Denom()
+	g := GCD(b, d)
+	x, ok1 := MulChecked(r.num, d/g)
+	y, ok2 := MulChecked(o.num, b/g)
+	n, ok3 := AddChecked(x, y)
+	den, ok4 := MulChecked(b/g, d)
+	if ok1 && ok2 && ok3 && ok4 {
+		return reduceRational(n, den)
+	}
+	return r.viaBig(new(big.Rat).Add(r.Big(), o.Big()))
+}
+
+// Sub returns r-o, and false if the result doesn't fit in a Rational[I].
+func (r Rational[I]) Sub(o Rational[I]) (Rational[I], bool) {
+	if o.num == MinOf[I]() {
+		return r.viaBig(new(big.Rat).Sub(r.Big(), o.Big()))
+	}
+	return r.Add(Rational[I]{num: -o.num, den: o.den})
+}
+
+// Mul returns r*o, and false if the result doesn't fit in a Rational[I].
+func (r Rational[I]) Mul(o Rational[I]) (Rational[I], bool) {
+	// Cross-reduce first so the products are as small as possible: (a/b) * (c/d) = (a/g1 * c/g2) / (b/g2 * d/g1)
+	// Denominators are always positive, so neither GCD can be 0.
+	g1 := GCD(r.num, o.Denom())
+	g2 := GCD(o.num, r.Denom())
+	n, ok1 := MulChecked(r.num/g1, o.num/g2)
+	d, ok2 := MulChecked(r.Denom()/g2, o.Denom()/g1)
+	if ok1 && ok2 {
+		return reduceRational(n, d)
+	}
+	return r.viaBig(new(big.Rat).Mul(r.Big(), o.Big()))
+}
+
+// Div returns r/o, and false if the result doesn't fit in a Rational[I]. It panics if o is zero, like integer division.
+func (r Rational[I]) Div(o Rational[I]) (Rational[I], bool) {
+	if o.num == 0 {
+		panic("num: Rational division by zero")
+	}
+	inv, ok := reduceRational(o.Denom(), o.num)
+	if !ok {
+		return r.viaBig(new(big.Rat).Quo(r.Big(), o.Big()))
+	}
+	return r.Mul(inv)
+}
+
+// Neg returns -r, and false if the result doesn't fit (only when the numerator is MinOf[I]()).
+func (r Rational[I]) Neg() (Rational[I], bool) {
+	if r.num == MinOf[I]() {
+		return Rational[I]{}, false
+	}
+	return Rational[I]{num: -r.num, den: r.den}, true
+}
+
+// Cmp compares r and o, returning -1 if r < o, 0 if r == o, and +1 if r > o.
+func (r Rational[I]) Cmp(o Rational[I]) int {
+	if r == o {
+		return 0
+	}
+	x, ok1 := MulChecked(r.num, o.Denom())
+	y, ok2 := MulChecked(o.num, r.Denom())
+	if !ok1 || !ok2 {
+		return r.Big().Cmp(o.Big())
+	}
+	if x < y {
+		return -1
+	}
+	return 1
+}
+
+// Sign returns -1, 0, or +1 depending on the sign of r.
+func (r Rational[I]) Sign() int {
+	switch {
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsInt returns true if the denominator is 1.
+func (r Rational[I]) IsInt() bool {
+	return r.den == 0
+}
+
+// Float64 returns the nearest float64 to r.
+func (r Rational[I]) Float64() float64 {
+	// Integers up to 2^53 are exact in a float64, and a single IEEE division of exact values is correctly rounded.
+	const exact = 1 << 53
+	n, _ := magnitude(r.num)
+	if n <= exact && uint64(r.Denom()) <= exact {
+		return float64(r.num) / float64(r.Denom())
+	}
+	f, _ := r.Big().Float64()
+	return f
+}
+
+// Big returns r as a newly allocated big.Rat.
+func (r Rational[I]) Big() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(int64(r.num)), big.NewInt(int64(r.Denom())))
+}
+
+// String returns r in the form "n/d", which ParseRational accepts. Integers are still written as "n/1".
+func (r Rational[I]) String() string {
+	return strconv.FormatInt(int64(r.num), 10) + "/" + strconv.FormatInt(int64(r.Denom()), 10)
+}
+
+// viaBig is the slow path for arithmetic whose intermediate values overflowed, even if the final result may not.
+func (r Rational[I]) viaBig(result *big.Rat) (Rational[I], bool) {
+	out, err := RationalFromBig[I](result)
+	return out, err == nil
+}
+
+// reduceRational returns n/d in lowest terms with a positive denominator. d must be non-zero.
+func reduceRational[I Signed](n, d I) (Rational[I], bool) {
+	if n == 0 {
+		return Rational[I]{}, true
+	}
+	if g := GCD(n, d); g > 1 {
+		n, d = n/g, d/g
+	} else if g < 0 {
+		// GCD overflowed, so both are MinOf[I]() and this is 1/1.
+		n, d = 1, 1
+	}
+	if d < 0 {
+		if n == MinOf[I]() || d == MinOf[I]() {
+			return Rational[I]{}, false
+		}
+		n, d = -n, -d
+	}
+	return Rational[I]{num: n, den: d - 1}, true
+}
+
+func rationalError[I Signed](err error, v any) error {
+	var i I
+	return fmt.Errorf("%w: %v to Rational[%T]", err, v, i)
+}
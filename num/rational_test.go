@@ -0,0 +1,125 @@
+package num_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func TestRationalBasics(t *testing.T) {
+	var zero num.Rational[int]
+	ExpectedActual(t, "0/1", zero.String(), "zero value")
+	ExpectedActual(t, num.NewRational(0, 5), zero, "zero value equals 0/5")
+
+	r := num.NewRational(6, -8)
+	ExpectedActual(t, -3, r.Num(), "reduced num")
+	ExpectedActual(t, 4, r.Denom(), "reduced denom")
+	ExpectedActual(t, "-3/4", r.String(), "string")
+	ExpectedActual(t, -0.75, r.Float64(), "float")
+	ExpectedActual(t, -1, r.Sign(), "sign")
+	ExpectedActual(t, false, r.IsInt(), "not int")
+	ExpectedActual(t, true, num.NewRational(8, 4).IsInt(), "is int")
+
+	ExpectedActual(t, num.NewRational[int8](1, 1), num.NewRational[int8](math.MinInt8, math.MinInt8), "min/min")
+	_, ok := num.NewRational[int8](math.MinInt8, 1).Neg()
+	ExpectedActual(t, false, ok, "neg overflow")
+}
+
+func TestRationalArithmetic(t *testing.T) {
+	a, b := num.NewRational(1, 6), num.NewRational(3, 4)
+	sum, _ := a.Add(b)
+	ExpectedActual(t, num.NewRational(11, 12), sum, "add")
+	diff, _ := a.Sub(b)
+	ExpectedActual(t, num.NewRational(-7, 12), diff, "sub")
+	prod, _ := a.Mul(b)
+	ExpectedActual(t, num.NewRational(1, 8), prod, "mul")
+	quot, _ := a.Div(b)
+	ExpectedActual(t, num.NewRational(2, 9), quot, "div")
+	ExpectedActual(t, -1, a.Cmp(b), "cmp less")
+	ExpectedActual(t, 1, b.Cmp(a), "cmp greater")
+	ExpectedActual(t, 0, a.Cmp(num.NewRational(2, 12)), "cmp equal")
+
+	defer func() {
+		ExpectedActual(t, true, recover() != nil, "div by zero panics")
+	}()
+	a.Div(num.Rational[int]{})
+}
+
+// Checks every operation on a spread of int8 rationals against math/big, including whether it should overflow.
+func TestRationalMatchesBig(t *testing.T) {
+	var values []num.Rational[int8]
+	for n := math.MinInt8; n <= math.MaxInt8; n += 17 {
+		for d := 1; d <= math.MaxInt8; d += 21 {
+			values = append(values, num.NewRational(int8(n), int8(d)))
+		}
+	}
+
+	type op struct {
+		name string
+		rat  func(a, b num.Rational[int8]) (num.Rational[int8], bool)
+		big  func(z, a, b *big.Rat) *big.Rat
+	}
+	ops := []op{
+		{"add", num.Rational[int8].Add, (*big.Rat).Add},
+		{"sub", num.Rational[int8].Sub, (*big.Rat).Sub},
+		{"mul", num.Rational[int8].Mul, (*big.Rat).Mul},
+		{"div", num.Rational[int8].Div, (*big.Rat).Quo},
+	}
+
+	for _, a := range values {
+		for _, b := range values {
+			if !ExpectedActual(t, a.Big().Cmp(b.Big()), a.Cmp(b), "cmp") {
+				t.Logf("a=%s b=%s", a, b)
+				return
+			}
+			for _, o := range ops {
+				if o.name == "div" && b.Sign() == 0 {
+					continue
+				}
+				expected := o.big(new(big.Rat), a.Big(), b.Big())
+				expectedR, err := num.RationalFromBig[int8](expected)
+
+				actual, ok := o.rat(a, b)
+				if !ExpectedActual(t, err == nil, ok, o.name+" ok") || (ok && !ExpectedActual(t, expectedR, actual, o.name)) {
+					t.Logf("a=%s b=%s expected=%s", a, b, expected.RatString())
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestRationalConversion(t *testing.T) {
+	r, err := num.ParseRational[int32]("3/4")
+	ExpectedActual(t, nil, err, "parse fraction error")
+	ExpectedActual(t, num.NewRational[int32](3, 4), r, "parse fraction")
+	r, err = num.ParseRational[int32]("-0.125")
+	ExpectedActual(t, nil, err, "parse decimal error")
+	ExpectedActual(t, num.NewRational[int32](-1, 8), r, "parse decimal")
+	r, err = num.ParseRational[int32](num.NewRational[int32](16, 9).String())
+	ExpectedActual(t, nil, err, "round trip error")
+	ExpectedActual(t, num.NewRational[int32](16, 9), r, "round trip")
+
+	_, err = num.ParseRational[int32]("3/0")
+	ExpectedActual(t, true, errors.Is(err, num.ErrInvalidRational), "zero denominator")
+	_, err = num.ParseRational[int32]("three quarters")
+	ExpectedActual(t, true, errors.Is(err, num.ErrInvalidRational), "invalid")
+	_, err = num.ParseRational[int8]("1/1000")
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "parse overflow")
+
+	r64, err := num.RationalFromFloat[int64](0.375)
+	ExpectedActual(t, nil, err, "from float error")
+	ExpectedActual(t, num.NewRational[int64](3, 8), r64, "from float")
+	_, err = num.RationalFromFloat[int32](0.1)
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "from float overflow")
+	_, err = num.RationalFromFloat[int64](math.Inf(1))
+	ExpectedActual(t, true, errors.Is(err, num.ErrNotFinite), "from float inf")
+
+	huge := num.NewRational[int64](math.MaxInt64, math.MaxInt64-1)
+	expected, _ := huge.Big().Float64()
+	ExpectedActual(t, expected, huge.Float64(), "large float conversion")
+}
@@ -0,0 +1,170 @@
+// Package units contains human-readable sizes and quantities, such as "512MiB" or "10k".
+// Each type implements encoding.TextMarshaler and encoding.TextUnmarshaler, so they can be used directly in config
+// structs loaded by fileload.JSON or fileload.TOML - just write the value as a string.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// ByteSize is a number of bytes.
+type ByteSize uint64
+
+// SI (decimal) byte sizes.
+const (
+	Byte ByteSize = 1
+	KB            = 1000 * Byte
+	MB            = 1000 * KB
+	GB            = 1000 * MB
+	TB            = 1000 * GB
+	PB            = 1000 * TB
+	EB            = 1000 * PB
+)
+
+// IEC (binary) byte sizes.
+const (
+	KiB = 1024 * Byte
+	MiB = 1024 * KiB
+	GiB = 1024 * MiB
+	TiB = 1024 * GiB
+	PiB = 1024 * TiB
+	EiB = 1024 * PiB
+)
+
+var ErrInvalidSize = errors.New("invalid size")
+
+type byteUnit struct {
+	name string
+	size ByteSize
+}
+
+// Largest first, so formatting can use the first unit that fits.
+var (
+	iecUnits = []byteUnit{{"EiB", EiB}, {"PiB", PiB}, {"TiB", TiB}, {"GiB", GiB}, {"MiB", MiB}, {"KiB", KiB}}
+	siUnits  = []byteUnit{{"EB", EB}, {"PB", PB}, {"TB", TB}, {"GB", GB}, {"MB", MB}, {"kB", KB}}
+)
+
+// byteSuffixes maps every accepted (lowercased) suffix to its size. The single letters are SI, like the
+// prefixes in Quantity - use the "i" forms if you want powers of 1024.
+var byteSuffixes = map[string]ByteSize{
+	"": Byte, "b": Byte,
+	"k": KB, "kb": KB, "m": MB, "mb": MB, "g": GB, "gb": GB, "t": TB, "tb": TB, "p": PB, "pb": PB, "e": EB, "eb": EB,
+	"ki": KiB, "kib": KiB, "mi": MiB, "mib": MiB, "gi": GiB, "gib": GiB, "ti": TiB, "tib": TiB, "pi": PiB, "pib": PiB, "ei": EiB, "eib": EiB,
+}
+
+// ParseByteSize parses a size such as "512MiB", "1.5GB", "10k", or "4096". Units are case-insensitive and may be
+// separated from the number by a space. Fractional sizes are rounded to the nearest byte, so "0.5KiB" is 512.
+func ParseByteSize(s string) (ByteSize, error) {
+	numPart, suffix := splitNumber(strings.TrimSpace(s))
+	unit, ok := byteSuffixes[strings.ToLower(strings.TrimSpace(suffix))]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown unit in %q", ErrInvalidSize, s)
+	}
+	// Exact math, so that large sizes like "15.5EiB" aren't subject to float rounding.
+	r, ok := new(big.Rat).SetString(numPart)
+	if !ok || !isDecimal(numPart) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSize, s)
+	}
+	r.Mul(r, new(big.Rat).SetUint64(uint64(unit)))
+	bytes := new(big.Int).Quo(new(big.Int).Add(new(big.Int).Lsh(r.Num(), 1), r.Denom()), new(big.Int).Lsh(r.Denom(), 1))
+	if !bytes.IsUint64() {
+		return 0, fmt.Errorf("%w: %q", num.ErrOverflow, s)
+	}
+	return ByteSize(bytes.Uint64()), nil
+}
+
+// String returns the size using the largest IEC unit that's at least 1, with up to 2 decimal places, eg "1.5GiB" or
+// "512B". This is rounded for humans to read - MarshalText is used for an exact value.
+func (b ByteSize) String() string {
+	return formatBytes(b, iecUnits)
+}
+
+// FormatSI is like String, but uses SI (powers of 1000) units, eg "1.5GB".
+func (b ByteSize) FormatSI() string {
+	return formatBytes(b, siUnits)
+}
+
+// MarshalText implements encoding.TextMarshaler. It uses the largest unit that represents b exactly, eg "512MiB" or
+// "3GB", falling back to a plain number of bytes.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	for i := range iecUnits {
+		// Check both unit systems from largest to smallest, since neither is a subset of the other.
+		for _, u := range []byteUnit{iecUnits[i], siUnits[i]} {
+			if b != 0 && b%u.size == 0 {
+				return []byte(strconv.FormatUint(uint64(b/u.size), 10) + u.name), nil
+			}
+		}
+	}
+	return []byte(strconv.FormatUint(uint64(b), 10) + "B"), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, using ParseByteSize.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	v, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// formatBytes formats b in the largest of units (which go from largest to smallest) that it's at least 1 of, to 2
+// decimal places.
+func formatBytes(b ByteSize, units []byteUnit) string {
+	for i, u := range units {
+		if b < u.size {
+			continue
+		}
+		v := math.Round(float64(b)/float64(u.size)*100) / 100
+		// Rounding can reach the next unit up, eg MiB-1 is 1023.999KiB, which should be 1MiB rather than 1024KiB.
+		if i > 0 && v >= float64(units[i-1].size)/float64(u.size) {
+			u = units[i-1]
+			v = math.Round(float64(b)/float64(u.size)*100) / 100
+		}
+		return trimZeros(num.Format(v, 2, "")) + u.name
+	}
+	return strconv.FormatUint(uint64(b), 10) + "B"
+}
+
+// splitNumber splits s into its leading decimal number and the remaining suffix.
+func splitNumber(s string) (number, suffix string) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+// isDecimal returns true if s is only digits with at most one decimal point. big.Rat accepts far more than this,
+// such as exponents and fractions, which we don't want in sizes.
+func isDecimal(s string) bool {
+	digits, dots := 0, 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+// trimZeros removes trailing zeros after a decimal point, and the point itself if nothing is left after it.
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	return strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+}
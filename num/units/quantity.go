@@ -0,0 +1,126 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// Quantity is a unitless number that's written with an SI prefix, eg "10k" for 10,000 or "250m" for 0.25.
+// Prefixes are case-sensitive, since "m" (milli) and "M" (mega) are very different.
+type Quantity float64
+
+var ErrInvalidQuantity = errors.New("invalid quantity")
+
+type siPrefix struct {
+	name string
+	exp  int // Power of 10
+}
+
+// Largest first, so formatting can use the first prefix that fits.
+var siPrefixes = []siPrefix{
+	{"E", 18}, {"P", 15}, {"T", 12}, {"G", 9}, {"M", 6}, {"k", 3},
+	{"", 0},
+	{"m", -3}, {"u", -6}, {"n", -9}, {"p", -12},
+}
+
+// apply returns v scaled up by the prefix, eg 2.5 with "k" is 2500.
+// Negative powers of 10 aren't exact as floats, so we divide by the positive power instead of multiplying by them.
+func (p siPrefix) apply(v float64) float64 {
+	if p.exp < 0 {
+		return v / math.Pow10(-p.exp)
+	}
+	return v * math.Pow10(p.exp)
+}
+
+// remove is the inverse of apply, eg 2500 with "k" is 2.5.
+func (p siPrefix) remove(v float64) float64 {
+	if p.exp < 0 {
+		return v * math.Pow10(-p.exp)
+	}
+	return v / math.Pow10(p.exp)
+}
+
+// ParseQuantity parses a number with an optional SI prefix, such as "10k", "2.5M", "500m", or "42".
+// Both "u" and "µ" are accepted for micro, and "K" is accepted for kilo since it's a common mistake.
+func ParseQuantity(s string) (Quantity, error) {
+	// Plain numbers, including exponents such as "1e-20", don't need any special handling.
+	if v, err := num.Parse[float64](strings.TrimSpace(s)); err == nil {
+		return Quantity(v), nil
+	}
+
+	numPart, prefix := splitNumber(strings.TrimSpace(s))
+	prefix = strings.TrimSpace(prefix)
+	switch prefix {
+	case "µ":
+		prefix = "u"
+	case "K":
+		prefix = "k"
+	}
+
+	for _, p := range siPrefixes {
+		if p.name != prefix {
+			continue
+		}
+		v, err := num.Parse[float64](numPart)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidQuantity, s)
+		}
+		return Quantity(p.apply(v)), nil
+	}
+	return 0, fmt.Errorf("%w: unknown prefix in %q", ErrInvalidQuantity, s)
+}
+
+// String returns q using the largest SI prefix that keeps the number at least 1, rounded to at most 3 decimal places,
+// eg "10k", "2.5M", or "250m". This is rounded for humans to read - MarshalText is used for an exact value.
+func (q Quantity) String() string {
+	f := float64(q)
+	if f == 0 || !num.IsFinite(f) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	abs := math.Abs(f)
+	for i, p := range siPrefixes {
+		if abs < p.apply(1) {
+			continue
+		}
+		v := math.Round(p.remove(f)*1000) / 1000
+		// Rounding can reach the next prefix up, eg 999999.9 is 1000k, which should be 1M.
+		if i > 0 && math.Abs(v) >= 1000 {
+			p = siPrefixes[i-1]
+			v = math.Round(p.remove(f)*1000) / 1000
+		}
+		return trimZeros(num.Format(v, 3, "")) + p.name
+	}
+	// Smaller than the smallest prefix, so there's nothing better than an exponent.
+	return strconv.FormatFloat(f, 'g', 4, 64)
+}
+
+// MarshalText implements encoding.TextMarshaler. Unlike String, this is exact - it uses the largest prefix that still
+// round trips to the same value, falling back to a plain number.
+func (q Quantity) MarshalText() ([]byte, error) {
+	f := float64(q)
+	for _, p := range siPrefixes {
+		if math.Abs(f) < p.apply(1) {
+			continue
+		}
+		s := strconv.FormatFloat(p.remove(f), 'f', -1, 64) + p.name
+		if parsed, err := ParseQuantity(s); err == nil && parsed == q {
+			return []byte(s), nil
+		}
+	}
+	return []byte(strconv.FormatFloat(f, 'g', -1, 64)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, using ParseQuantity.
+func (q *Quantity) UnmarshalText(text []byte) error {
+	v, err := ParseQuantity(string(text))
+	if err != nil {
+		return err
+	}
+	*q = v
+	return nil
+}
@@ -0,0 +1,156 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/fileload"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func TestParseByteSize(t *testing.T) {
+	c := []struct {
+		In       string
+		Expected ByteSize
+	}{
+		{"4096", 4096},
+		{"512B", 512},
+		{"512MiB", 512 * MiB},
+		{"512 mib", 512 * MiB},
+		{"1.5GB", 1500 * MB},
+		{"1.5GiB", 1536 * MiB},
+		{"10k", 10 * KB},
+		{"10Ki", 10 * KiB},
+		{"0.5KiB", 512},
+		{"0.0005kB", 1}, // Rounded to the nearest byte
+		{" 2 TB ", 2 * TB},
+		{"0", 0},
+		{"18446744073709551615", math.MaxUint64},
+	}
+	for _, tc := range c {
+		v, err := ParseByteSize(tc.In)
+		ExpectedActual(t, nil, err, tc.In+" error")
+		ExpectedActual(t, tc.Expected, v, tc.In)
+	}
+
+	v, err := ParseByteSize("15.999999999999999999EiB")
+	ExpectedActual(t, nil, err, "near max error")
+	ExpectedActual(t, ByteSize(math.MaxUint64), v, "near max")
+	// 16EiB is 2^64, which won't fit.
+	_, err = ParseByteSize("16EiB")
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "overflow")
+
+	for _, bad := range []string{"", "MiB", "-1MiB", "1XB", "1.2.3MB", "1e6", "1/2MB", ".MB"} {
+		_, err := ParseByteSize(bad)
+		ExpectedActual(t, true, errors.Is(err, ErrInvalidSize), "invalid "+bad)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	ExpectedActual(t, "512B", ByteSize(512).String(), "bytes")
+	ExpectedActual(t, "1.5GiB", (1536 * MiB).String(), "iec")
+	ExpectedActual(t, "1.61GB", (1536 * MiB).FormatSI(), "si")
+	ExpectedActual(t, "1023B", ByteSize(1023).String(), "just under KiB")
+	ExpectedActual(t, "16EiB", ByteSize(math.MaxUint64).String(), "max rounds up")
+	ExpectedActual(t, "0B", ByteSize(0).String(), "zero")
+	ExpectedActual(t, "1MiB", (MiB - 1).String(), "rounds up to the next unit")
+	ExpectedActual(t, "1023.99KiB", (MiB - 10).String(), "just under MiB")
+	ExpectedActual(t, "1GB", (GB - 1).FormatSI(), "si rounds up to the next unit")
+	ExpectedActual(t, "999B", ByteSize(999).FormatSI(), "si bytes stay exact")
+
+	c := []struct {
+		In       ByteSize
+		Expected string
+	}{
+		{512 * MiB, "512MiB"},
+		{3 * GB, "3GB"},
+		{1536 * MiB, "1536MiB"},
+		{1000 * KiB, "1000KiB"},
+		{1001, "1001B"},
+		{0, "0B"},
+		{math.MaxUint64, "18446744073709551615B"},
+	}
+	for _, tc := range c {
+		text, err := tc.In.MarshalText()
+		ExpectedActual(t, nil, err, tc.Expected+" marshal error")
+		ExpectedActual(t, tc.Expected, string(text), tc.Expected+" marshal")
+		var back ByteSize
+		ExpectedActual(t, nil, back.UnmarshalText(text), tc.Expected+" unmarshal error")
+		ExpectedActual(t, tc.In, back, tc.Expected+" round trip")
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	c := []struct {
+		In       string
+		Expected Quantity
+		Out      string
+	}{
+		{"10k", 10000, "10k"},
+		{"10K", 10000, "10k"},
+		{"2.5M", 2.5e6, "2.5M"},
+		{"250m", 0.25, "250m"},
+		{"3µ", 3e-6, "3u"},
+		{"-1.5G", -1.5e9, "-1.5G"},
+		{"42", 42, "42"},
+		{"1e-20", 1e-20, "1e-20"},
+		{"1234", 1234, "1.234k"},
+	}
+	for _, tc := range c {
+		q, err := ParseQuantity(tc.In)
+		ExpectedActual(t, nil, err, tc.In+" error")
+		ExpectedActual(t, tc.Expected, q, tc.In)
+		ExpectedActual(t, tc.Out, q.String(), tc.In+" string")
+
+		text, err := q.MarshalText()
+		ExpectedActual(t, nil, err, tc.In+" marshal error")
+		var back Quantity
+		ExpectedActual(t, nil, back.UnmarshalText(text), tc.In+" unmarshal error")
+		ExpectedActual(t, q, back, tc.In+" round trip "+string(text))
+	}
+
+	for _, bad := range []string{"", "k", "10x", "10 kk", "1.2.3k"} {
+		_, err := ParseQuantity(bad)
+		ExpectedActual(t, true, errors.Is(err, ErrInvalidQuantity), "invalid "+bad)
+	}
+}
+
+func TestQuantityStringRounding(t *testing.T) {
+	ExpectedActual(t, "1M", Quantity(999999.9).String(), "rounds up to M")
+	ExpectedActual(t, "-1M", Quantity(-999999.9).String(), "negative rounds up to M")
+	ExpectedActual(t, "1", Quantity(0.9999999).String(), "rounds up to no prefix")
+	ExpectedActual(t, "999.999k", Quantity(999999).String(), "just under M")
+	ExpectedActual(t, "1.235k", Quantity(1234.5678).String(), "3 decimal places")
+}
+
+type serviceCfg struct {
+	MaxUpload ByteSize
+	Cache     ByteSize
+	RateLimit Quantity
+}
+
+func TestConfigLoad(t *testing.T) {
+	dir := t.TempDir()
+	tomlFile := filepath.Join(dir, "cfg.toml")
+	jsonFile := filepath.Join(dir, "cfg.json")
+	ExpectedActual(t, nil, os.WriteFile(tomlFile, []byte("MaxUpload = \"512MiB\"\nCache = 4096\nRateLimit = \"10k\"\n"), 0o600), "writing toml")
+	ExpectedActual(t, nil, os.WriteFile(jsonFile, []byte(`{"MaxUpload": "512MiB", "Cache": "4KiB", "RateLimit": "10k"}`), 0o600), "writing json")
+	expected := serviceCfg{MaxUpload: 512 * MiB, Cache: 4 * KiB, RateLimit: 10000}
+
+	cfg, _, err := fileload.TOML[serviceCfg](tomlFile)
+	ExpectedActual(t, nil, err, "loading toml")
+	ExpectedActual(t, expected, *cfg, "toml")
+
+	cfg, err = fileload.JSON[serviceCfg](jsonFile)
+	ExpectedActual(t, nil, err, "loading json")
+	ExpectedActual(t, expected, *cfg, "json")
+
+	data, err := json.Marshal(expected)
+	ExpectedActual(t, nil, err, "marshal json")
+	ExpectedActual(t, `{"MaxUpload":"512MiB","Cache":"4KiB","RateLimit":"10k"}`, string(data), "json output")
+}
@@ -0,0 +1,146 @@
+// Package stackerr wraps errors with the call stack where they were created, so a logged error can say where it came
+// from. Capturing only saves the program counters, which is cheap - turning them into function names and lines only
+// happens when the stack is printed.
+//
+// The errors work with errors.Is and errors.As like any other wrapped error, and print their stack with %+v:
+//
+//	err := stackerr.Errorf("loading %s: %w", name, err)
+//	fmt.Printf("%+v", err)
+package stackerr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxDepth is the most frames captured. Deeper stacks are cut off at the outermost callers, which are rarely useful.
+const maxDepth = 32
+
+// Stack is a captured call stack.
+type Stack struct {
+	pcs    []uintptr
+	once   sync.Once
+	frames []runtime.Frame
+}
+
+// Stacker is implemented by errors that carry a stack, which is how StackOf finds them.
+type Stacker interface {
+	Stack() *Stack
+}
+
+// withStack is an error with the stack where it was created.
+type withStack struct {
+	err   error
+	stack *Stack
+}
+
+// New returns an error with the given message and the caller's stack.
+func New(msg string) error {
+	return &withStack{err: errors.New(msg), stack: capture()}
+}
+
+// Errorf is like fmt.Errorf, including %w, and adds the caller's stack.
+func Errorf(format string, args ...any) error {
+	return &withStack{err: fmt.Errorf(format, args...), stack: capture()}
+}
+
+// Wrap adds the caller's stack to err, keeping its message. Returns nil if err is nil, so it's safe to use on any
+// returned error, eg `return stackerr.Wrap(err)`.
+func Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withStack{err: err, stack: capture()}
+}
+
+// StackOf returns the deepest stack in err's chain, which is the closest to where the error first happened, or nil if
+// there isn't one. This follows errors.Join and multiple %w, checking earlier errors first.
+func StackOf(err error) *Stack {
+	if err == nil {
+		return nil
+	}
+	var stack *Stack
+	if s, ok := err.(Stacker); ok {
+		stack = s.Stack()
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if deeper := StackOf(u.Unwrap()); deeper != nil {
+			return deeper
+		}
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if deeper := StackOf(e); deeper != nil {
+				return deeper
+			}
+		}
+	}
+	return stack
+}
+
+func (e *withStack) Error() string {
+	return e.err.Error()
+}
+
+func (e *withStack) Unwrap() error {
+	return e.err
+}
+
+// Stack returns the stack where the error was created.
+func (e *withStack) Stack() *Stack {
+	return e.stack
+}
+
+// Format implements fmt.Formatter. %+v prints the message followed by the stack, and everything else prints the
+// message like a normal error.
+func (e *withStack) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		_, _ = io.WriteString(f, e.Error()+"\n"+e.stack.String())
+	case verb == 'q':
+		_, _ = io.WriteString(f, strconv.Quote(e.Error()))
+	default:
+		_, _ = io.WriteString(f, e.Error())
+	}
+}
+
+// Frames returns the stack's frames, from the innermost call outwards, or nil if the stack is empty.
+func (s *Stack) Frames() []runtime.Frame {
+	s.once.Do(func() {
+		if len(s.pcs) == 0 {
+			return
+		}
+		frames := runtime.CallersFrames(s.pcs)
+		for {
+			frame, more := frames.Next()
+			s.frames = append(s.frames, frame)
+			if !more {
+				break
+			}
+		}
+	})
+	return s.frames
+}
+
+// String returns the stack formatted like a panic's, with each function followed by its file and line on the next
+// line, indented.
+func (s *Stack) String() string {
+	var sb strings.Builder
+	for _, f := range s.Frames() {
+		sb.WriteString(f.Function + "\n\t" + f.File + ":" + strconv.Itoa(f.Line) + "\n")
+	}
+	return sb.String()
+}
+
+// capture returns the stack of the caller's caller, ie whoever called New, Errorf, or Wrap.
+func capture() *Stack {
+	var pcs [maxDepth]uintptr
+	n := runtime.Callers(3, pcs[:]) // Skips runtime.Callers, capture, and the stackerr function
+	return &Stack{pcs: append([]uintptr(nil), pcs[:n]...)}
+}
@@ -0,0 +1,75 @@
+package stackerr
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func loadConfig() error {
+	return Errorf("loading config: %w", fs.ErrNotExist)
+}
+
+func startService() error {
+	return Wrap(fmt.Errorf("starting: %w", loadConfig()))
+}
+
+func TestStack(t *testing.T) {
+	err := loadConfig()
+	ExpectedActual(t, "loading config: file does not exist", err.Error(), "message")
+	ExpectedActual(t, true, errors.Is(err, fs.ErrNotExist), "is")
+
+	frames := StackOf(err).Frames()
+	ExpectedActual(t, "github.com/seanpfeifer/rigging/stackerr.loadConfig", frames[0].Function, "innermost frame")
+	ExpectedActual(t, "github.com/seanpfeifer/rigging/stackerr.TestStack", frames[1].Function, "caller")
+	ExpectedActual(t, true, strings.HasSuffix(frames[0].File, "stackerr_test.go"), "file")
+
+	ExpectedActual(t, "loading config: file does not exist", fmt.Sprintf("%v", err), "%v")
+	ExpectedActual(t, "loading config: file does not exist", fmt.Sprintf("%s", err), "%s")
+	ExpectedActual(t, `"loading config: file does not exist"`, fmt.Sprintf("%q", err), "%q")
+	detailed := fmt.Sprintf("%+v", err)
+	ExpectedActual(t, true, strings.HasPrefix(detailed, "loading config: file does not exist\ngithub.com/seanpfeifer/rigging/stackerr.loadConfig\n\t"), "%+v: "+detailed)
+	ExpectedActual(t, true, strings.Contains(detailed, "stackerr.TestStack\n\t"), "%+v caller")
+}
+
+func TestStackOfDeepest(t *testing.T) {
+	err := startService()
+	ExpectedActual(t, "starting: loading config: file does not exist", err.Error(), "message")
+	ExpectedActual(t, true, errors.Is(err, fs.ErrNotExist), "is through both")
+	ExpectedActual(t, "github.com/seanpfeifer/rigging/stackerr.loadConfig", StackOf(err).Frames()[0].Function, "deepest")
+
+	var pathErr *fs.PathError
+	wrapped := Wrap(&fs.PathError{Op: "open", Path: "cfg.toml", Err: fs.ErrPermission})
+	ExpectedActual(t, true, errors.As(wrapped, &pathErr), "as")
+	ExpectedActual(t, "cfg.toml", pathErr.Path, "as value")
+
+	joined := errors.Join(errors.New("plain"), New("first with stack"), loadConfig())
+	ExpectedActual(t, "github.com/seanpfeifer/rigging/stackerr.TestStackOfDeepest", StackOf(joined).Frames()[0].Function, "joined uses the first")
+
+	ExpectedActual(t, (*Stack)(nil), StackOf(errors.New("plain")), "no stack")
+	ExpectedActual(t, (*Stack)(nil), StackOf(nil), "nil")
+	ExpectedActual(t, nil, Wrap(nil), "wrap nil")
+}
+
+func TestEmptyStack(t *testing.T) {
+	var empty Stack
+	ExpectedActual(t, []runtime.Frame(nil), empty.Frames(), "no frames")
+	ExpectedActual(t, "", empty.String(), "no text")
+}
+
+func BenchmarkNew(b *testing.B) {
+	for b.Loop() {
+		_ = New("failed")
+	}
+}
+
+func BenchmarkNewAndFormat(b *testing.B) {
+	for b.Loop() {
+		_ = fmt.Sprintf("%+v", New("failed"))
+	}
+}
@@ -0,0 +1,154 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// Accumulator keeps a running count, mean, variance, min, and max without storing the values, using Welford's
+// algorithm so the variance stays accurate even when the values are large and close together.
+// The zero value is ready to use.
+type Accumulator[N num.Real] struct {
+	n        int
+	mean     float64
+	m2       float64 // Sum of squared differences from the mean
+	min, max N
+}
+
+// Add adds x to the accumulator. NaN values are ignored.
+func (a *Accumulator[N]) Add(x N) {
+	if isNaN(x) {
+		return
+	}
+	f := float64(x)
+	a.n++
+	if a.n == 1 {
+		a.min, a.max = x, x
+	} else {
+		a.min, a.max = min(a.min, x), max(a.max, x)
+	}
+	delta := f - a.mean
+	a.mean += delta / float64(a.n)
+	a.m2 += delta * (f - a.mean)
+}
+
+// AddAll adds each of xs to the accumulator.
+func (a *Accumulator[N]) AddAll(xs []N) {
+	for _, x := range xs {
+		a.Add(x)
+	}
+}
+
+// Merge adds everything in other to a, as if every value added to other was added to a instead.
+// This is handy for combining per-goroutine or per-shard accumulators.
+func (a *Accumulator[N]) Merge(other Accumulator[N]) {
+	if other.n == 0 {
+		return
+	}
+	if a.n == 0 {
+		*a = other
+		return
+	}
+	n := a.n + other.n
+	delta := other.mean - a.mean
+	a.m2 += other.m2 + delta*delta*float64(a.n)*float64(other.n)/float64(n)
+	a.mean += delta * float64(other.n) / float64(n)
+	a.n = n
+	a.min, a.max = min(a.min, other.min), max(a.max, other.max)
+}
+
+// Reset clears the accumulator so it can be reused.
+func (a *Accumulator[N]) Reset() {
+	*a = Accumulator[N]{}
+}
+
+// Count returns the number of values added, not counting NaNs.
+func (a *Accumulator[N]) Count() int {
+	return a.n
+}
+
+// Mean returns the mean of the values added, or NaN if there are none.
+func (a *Accumulator[N]) Mean() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.mean
+}
+
+// Variance returns the population variance of the values added, or NaN if there are none.
+func (a *Accumulator[N]) Variance() float64 {
+	if a.n == 0 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n)
+}
+
+// SampleVariance returns the sample variance (with Bessel's correction) of the values added, or NaN if there are
+// fewer than 2.
+func (a *Accumulator[N]) SampleVariance() float64 {
+	if a.n < 2 {
+		return math.NaN()
+	}
+	return a.m2 / float64(a.n-1)
+}
+
+// StdDev returns the population standard deviation of the values added, or NaN if there are none.
+func (a *Accumulator[N]) StdDev() float64 {
+	return math.Sqrt(a.Variance())
+}
+
+// SampleStdDev returns the sample standard deviation of the values added, or NaN if there are fewer than 2.
+func (a *Accumulator[N]) SampleStdDev() float64 {
+	return math.Sqrt(a.SampleVariance())
+}
+
+// Min returns the smallest value added, or zero if there are none.
+func (a *Accumulator[N]) Min() N {
+	return a.min
+}
+
+// Max returns the largest value added, or zero if there are none.
+func (a *Accumulator[N]) Max() N {
+	return a.max
+}
+
+// EMA is an exponential moving average, where each new value has a weight of alpha and the previous average has a
+// weight of 1-alpha. It's a cheap way to smooth noisy values like frame times or request latencies.
+type EMA[N num.Real] struct {
+	alpha float64
+	value float64
+	init  bool
+}
+
+// NewEMA returns an EMA with the given smoothing factor, which must be in (0, 1]. Higher values react faster.
+// A common choice is 2/(n+1), which is roughly an average over the last n values.
+func NewEMA[N num.Real](alpha float64) *EMA[N] {
+	if !(alpha > 0 && alpha <= 1) {
+		panic("stats: NewEMA alpha must be in (0, 1]")
+	}
+	return &EMA[N]{alpha: alpha}
+}
+
+// Add adds x to the average and returns the new average. The first value becomes the average as-is.
+// NaN values are ignored.
+func (e *EMA[N]) Add(x N) float64 {
+	if isNaN(x) {
+		return e.Value()
+	}
+	if !e.init {
+		e.value = float64(x)
+		e.init = true
+	} else {
+		e.value += e.alpha * (float64(x) - e.value)
+	}
+	return e.value
+}
+
+// Value returns the current average, or NaN if nothing has been added.
+func (e *EMA[N]) Value() float64 {
+	if !e.init {
+		return math.NaN()
+	}
+	return e.value
+}
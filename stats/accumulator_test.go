@@ -0,0 +1,66 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestAccumulator(t *testing.T) {
+	var acc Accumulator[int]
+	ExpectedActual(t, true, math.IsNaN(acc.Mean()), "empty mean")
+	ExpectedActual(t, true, math.IsNaN(acc.Variance()), "empty variance")
+
+	acc.AddAll([]int{2, 4, 4, 4, 5, 5, 7, 9})
+	ExpectedActual(t, 8, acc.Count(), "count")
+	ExpectedActual(t, 5.0, acc.Mean(), "mean")
+	ExpectedActual(t, 4.0, acc.Variance(), "variance")
+	ExpectedActual(t, 2.0, acc.StdDev(), "stddev")
+	ExpectedApprox(t, math.Sqrt(32.0/7), acc.SampleStdDev(), 1e-12, "sample stddev")
+	ExpectedActual(t, 2, acc.Min(), "min")
+	ExpectedActual(t, 9, acc.Max(), "max")
+
+	acc.Reset()
+	ExpectedActual(t, 0, acc.Count(), "reset")
+}
+
+func TestAccumulatorMerge(t *testing.T) {
+	xs := []float64{1.5, 3, -2, 8, 0.25, 11, 4, 4, -7}
+	var all Accumulator[float64]
+	all.AddAll(xs)
+
+	for split := range len(xs) + 1 {
+		var a, b Accumulator[float64]
+		a.AddAll(xs[:split])
+		b.AddAll(xs[split:])
+		a.Merge(b)
+		ExpectedActual(t, all.Count(), a.Count(), "count")
+		ExpectedApprox(t, all.Mean(), a.Mean(), 1e-12, "mean")
+		ExpectedApprox(t, all.Variance(), a.Variance(), 1e-12, "variance")
+		ExpectedActual(t, all.Min(), a.Min(), "min")
+		ExpectedActual(t, all.Max(), a.Max(), "max")
+	}
+}
+
+func TestEMA(t *testing.T) {
+	ema := NewEMA[int](0.5)
+	ExpectedActual(t, true, math.IsNaN(ema.Value()), "empty")
+	ExpectedActual(t, 10.0, ema.Add(10), "first value")
+	ExpectedActual(t, 15.0, ema.Add(20), "second")
+	ExpectedActual(t, 10.0, ema.Add(5), "third")
+	ExpectedActual(t, 10.0, ema.Value(), "value")
+
+	// Converges on a constant input.
+	slow := NewEMA[float32](0.1)
+	slow.Add(0)
+	for range 200 {
+		slow.Add(100)
+	}
+	ExpectedApprox(t, 100, slow.Value(), 1e-6, "converges")
+
+	defer func() {
+		ExpectedActual(t, "stats: NewEMA alpha must be in (0, 1]", recover(), "bad alpha panics")
+	}()
+	NewEMA[int](0)
+}
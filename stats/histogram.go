@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// Histogram counts values into buckets defined by their upper bounds. A value x goes into the first bucket with
+// x <= bound, and values above the last bound go into an extra overflow bucket at the end, so there is always one more
+// count than there are bounds. This is the same layout as Prometheus histograms.
+type Histogram[N num.Real] struct {
+	bounds []N
+	counts []uint64
+	acc    Accumulator[N]
+}
+
+// NewHistogram returns a histogram with the given bucket upper bounds, which must be strictly increasing.
+// LinearBuckets and ExponentialBuckets can be used to make common bounds.
+func NewHistogram[N num.Real](bounds ...N) *Histogram[N] {
+	for i := 1; i < len(bounds); i++ {
+		if !(bounds[i-1] < bounds[i]) {
+			panic("stats: NewHistogram bounds must be strictly increasing")
+		}
+	}
+	return &Histogram[N]{
+		bounds: slices.Clone(bounds),
+		counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+// LinearBuckets returns count bounds, starting at start and each width apart. eg LinearBuckets(10, 5, 3) is [10 15 20].
+func LinearBuckets[N num.Real](start, width N, count int) []N {
+	if width <= 0 {
+		panic("stats: LinearBuckets width must be positive")
+	}
+	bounds := make([]N, count)
+	for i := range bounds {
+		bounds[i] = start + N(i)*width
+	}
+	return bounds
+}
+
+// ExponentialBuckets returns count bounds, starting at start and each factor times the previous.
+// eg ExponentialBuckets(1, 2, 4) is [1 2 4 8]. This is usually what you want for latencies.
+// For integer types each bound is rounded down, and it panics if that would make two bounds equal.
+func ExponentialBuckets[N num.Real](start N, factor float64, count int) []N {
+	if start <= 0 || !(factor > 1) {
+		panic("stats: ExponentialBuckets start must be positive and factor must be greater than 1")
+	}
+	bounds := make([]N, count)
+	b := float64(start)
+	for i := range bounds {
+		bounds[i] = N(b)
+		if i > 0 && bounds[i] <= bounds[i-1] {
+			panic("stats: ExponentialBuckets bounds are too close together for this type")
+		}
+		b *= factor
+	}
+	return bounds
+}
+
+// Add counts x in its bucket. NaN values are ignored.
+func (h *Histogram[N]) Add(x N) {
+	if isNaN(x) {
+		return
+	}
+	i := sort.Search(len(h.bounds), func(i int) bool { return x <= h.bounds[i] })
+	h.counts[i]++
+	h.acc.Add(x)
+}
+
+// Bounds returns a copy of the bucket upper bounds.
+func (h *Histogram[N]) Bounds() []N {
+	return slices.Clone(h.bounds)
+}
+
+// Counts returns a copy of the count in each bucket, with the overflow bucket last.
+func (h *Histogram[N]) Counts() []uint64 {
+	return slices.Clone(h.counts)
+}
+
+// Total returns the number of values added.
+func (h *Histogram[N]) Total() int {
+	return h.acc.Count()
+}
+
+// Summary returns the count, mean, variance, min, and max of the values added.
+func (h *Histogram[N]) Summary() Accumulator[N] {
+	return h.acc
+}
+
+// Reset clears all the counts, keeping the bounds.
+func (h *Histogram[N]) Reset() {
+	clear(h.counts)
+	h.acc.Reset()
+}
+
+// Percentile estimates the p-th percentile, where p is between 0 and 100, by finding the bucket it falls in and
+// interpolating linearly within it. The lowest and highest buckets are limited by the smallest and largest values seen.
+// p outside of 0 to 100 is clamped, and a NaN p panics. Returns NaN if nothing has been added.
+func (h *Histogram[N]) Percentile(p float64) float64 {
+	checkPercentile(p)
+	total := h.acc.Count()
+	if total == 0 {
+		return math.NaN()
+	}
+	rank := min(max(p, 0), 100) / 100 * float64(total)
+	lowest, highest := float64(h.acc.Min()), float64(h.acc.Max())
+
+	var cumulative float64
+	for i, c := range h.counts {
+		if c == 0 || cumulative+float64(c) < rank {
+			cumulative += float64(c)
+			continue
+		}
+		lo, hi := lowest, highest
+		if i > 0 {
+			lo = max(lo, float64(h.bounds[i-1]))
+		}
+		if i < len(h.bounds) {
+			hi = min(hi, float64(h.bounds[i]))
+		}
+		return lo + (hi-lo)*(rank-cumulative)/float64(c)
+	}
+	return highest
+}
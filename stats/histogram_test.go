@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestBuckets(t *testing.T) {
+	ExpectedActual(t, []int{10, 15, 20}, LinearBuckets(10, 5, 3), "linear")
+	ExpectedActual(t, []float64{0.5, 0.75, 1}, LinearBuckets(0.5, 0.25, 3), "linear float")
+	ExpectedActual(t, []int{1, 2, 4, 8}, ExponentialBuckets(1, 2, 4), "exponential")
+	ExpectedActual(t, []float64{1, 1.5, 2.25}, ExponentialBuckets(1.0, 1.5, 3), "exponential float")
+
+	defer func() {
+		ExpectedActual(t, "stats: ExponentialBuckets bounds are too close together for this type", recover(), "int rounding panics")
+	}()
+	ExponentialBuckets(1, 1.5, 3) // 1, 1.5, 2.25 rounds down to 1, 1, 2
+}
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram(10, 20, 50)
+	ExpectedActual(t, true, math.IsNaN(h.Percentile(50)), "empty percentile")
+	for _, x := range []int{1, 10, 11, 20, 21, 49, 50, 51, 1000} {
+		h.Add(x)
+	}
+	ExpectedActual(t, []uint64{2, 2, 3, 2}, h.Counts(), "counts include bound and overflow")
+	ExpectedActual(t, []int{10, 20, 50}, h.Bounds(), "bounds")
+	ExpectedActual(t, 9, h.Total(), "total")
+	summary := h.Summary()
+	ExpectedActual(t, 1000, summary.Max(), "summary max")
+
+	h.Reset()
+	ExpectedActual(t, []uint64{0, 0, 0, 0}, h.Counts(), "reset")
+	ExpectedActual(t, []int{10, 20, 50}, h.Bounds(), "reset keeps bounds")
+
+	fh := NewHistogram[float64]()
+	fh.Add(math.NaN())
+	fh.Add(1)
+	ExpectedActual(t, []uint64{1}, fh.Counts(), "no bounds and nan ignored")
+
+	defer func() {
+		ExpectedActual(t, "stats: NewHistogram bounds must be strictly increasing", recover(), "unsorted panics")
+	}()
+	NewHistogram(1, 3, 3)
+}
+
+func TestHistogramPercentile(t *testing.T) {
+	h := NewHistogram(LinearBuckets(10.0, 10, 10)...)
+	xs := make([]float64, 0, 1000)
+	for i := range 1000 {
+		x := float64(i) / 10 // Uniform over [0, 100)
+		xs = append(xs, x)
+		h.Add(x)
+	}
+	for _, p := range []float64{5, 50, 90, 99} {
+		ExpectedAlmostEqual(t, Percentile(xs, p), h.Percentile(p), 0.2, 0, "uniform")
+	}
+	ExpectedActual(t, 0.0, h.Percentile(0), "min")
+	ExpectedActual(t, 99.9, h.Percentile(100), "max")
+
+	// Values in the overflow bucket are limited by the max seen.
+	over := NewHistogram(10)
+	over.Add(5)
+	over.Add(20)
+	ExpectedActual(t, 20.0, over.Percentile(100), "overflow max")
+	ExpectedActual(t, 15.0, over.Percentile(75), "overflow interpolated")
+}
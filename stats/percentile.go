@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"math"
+	"slices"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// StreamingPercentile estimates a single percentile of a stream of values in constant memory, using the P² algorithm
+// from Jain and Chlamtac. It keeps 5 markers that track the min, max, the percentile itself, and the points halfway to
+// it on either side, nudging them towards their ideal positions as values are added.
+//
+// The estimate is exact for the first 5 values, and is usually within a few percent after that for smooth
+// distributions. Use Percentile instead if you can keep all the values.
+type StreamingPercentile[N num.Real] struct {
+	p       float64    // As a fraction, eg 0.99
+	count   int        // Total values added
+	heights [5]float64 // Marker heights, ie the estimated values
+	pos     [5]float64 // Actual marker positions, which are always whole numbers
+	desired [5]float64 // Ideal marker positions
+	incr    [5]float64 // How much each desired position moves per value
+}
+
+// NewStreamingPercentile returns an estimator for the p-th percentile, where p is between 0 and 100.
+func NewStreamingPercentile[N num.Real](p float64) *StreamingPercentile[N] {
+	if !(p >= 0 && p <= 100) {
+		panic("stats: NewStreamingPercentile p must be in [0, 100]")
+	}
+	q := p / 100
+	return &StreamingPercentile[N]{
+		p:       q,
+		pos:     [5]float64{0, 1, 2, 3, 4},
+		desired: [5]float64{0, 2 * q, 4 * q, 2 + 2*q, 4},
+		incr:    [5]float64{0, q / 2, q, (1 + q) / 2, 1},
+	}
+}
+
+// Add adds x to the estimate. NaN values are ignored.
+func (s *StreamingPercentile[N]) Add(x N) {
+	if isNaN(x) {
+		return
+	}
+	f := float64(x)
+	if s.count < len(s.heights) {
+		s.heights[s.count] = f
+		s.count++
+		if s.count == len(s.heights) {
+			slices.Sort(s.heights[:])
+		}
+		return
+	}
+	s.count++
+
+	// Find the cell that x falls in, extending the min or max marker if needed.
+	var k int
+	switch {
+	case f < s.heights[0]:
+		s.heights[0] = f
+		k = 0
+	case f >= s.heights[4]:
+		s.heights[4] = f
+		k = 3
+	default:
+		for f >= s.heights[k+1] {
+			k++ // Stops by k=3, since f < heights[4]
+		}
+	}
+	for i := k + 1; i < len(s.pos); i++ {
+		s.pos[i]++
+	}
+	for i := range s.desired {
+		s.desired[i] += s.incr[i]
+	}
+
+	// Move the middle markers one position towards where they should be, if they're off by at least one and there's
+	// room to move without running into a neighbour.
+	for i := 1; i <= 3; i++ {
+		d := s.desired[i] - s.pos[i]
+		if (d >= 1 && s.pos[i+1]-s.pos[i] > 1) || (d <= -1 && s.pos[i-1]-s.pos[i] < -1) {
+			d = math.Copysign(1, d)
+			h := s.parabolic(i, d)
+			if !(s.heights[i-1] < h && h < s.heights[i+1]) {
+				h = s.linear(i, d)
+			}
+			s.heights[i] = h
+			s.pos[i] += d
+		}
+	}
+}
+
+// parabolic predicts the height of marker i after moving it by d, by fitting a parabola through it and its neighbours.
+func (s *StreamingPercentile[N]) parabolic(i int, d float64) float64 {
+	h, n := &s.heights, &s.pos
+	return h[i] + d/(n[i+1]-n[i-1])*
+		((n[i]-n[i-1]+d)*(h[i+1]-h[i])/(n[i+1]-n[i])+
+			(n[i+1]-n[i]-d)*(h[i]-h[i-1])/(n[i]-n[i-1]))
+}
+
+// linear predicts the height of marker i after moving it by d towards the neighbour in that direction. This is the
+// fallback for when the parabola would put the marker out of order.
+func (s *StreamingPercentile[N]) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return s.heights[i] + d*(s.heights[j]-s.heights[i])/(s.pos[j]-s.pos[i])
+}
+
+// Value returns the current estimate, or NaN if nothing has been added.
+func (s *StreamingPercentile[N]) Value() float64 {
+	if s.count <= len(s.heights) {
+		// The markers haven't moved yet, so we can give an exact answer.
+		first := slices.Clone(s.heights[:s.count])
+		slices.Sort(first)
+		return sortedPercentile(first, s.p*100)
+	}
+	return s.heights[2]
+}
+
+// Count returns the number of values added, not counting NaNs.
+func (s *StreamingPercentile[N]) Count() int {
+	return s.count
+}
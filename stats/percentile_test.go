@@ -0,0 +1,56 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestStreamingPercentileExactWhenSmall(t *testing.T) {
+	s := NewStreamingPercentile[int](50)
+	ExpectedActual(t, true, math.IsNaN(s.Value()), "empty")
+	for i, x := range []int{40, 15, 35, 20, 50} {
+		s.Add(x)
+		ExpectedActual(t, i+1, s.Count(), "count")
+	}
+	ExpectedActual(t, 35.0, s.Value(), "median of 5")
+
+	p90 := NewStreamingPercentile[int](90)
+	for _, x := range []int{40, 15, 35} {
+		p90.Add(x)
+	}
+	ExpectedActual(t, Percentile([]int{40, 15, 35}, 90), p90.Value(), "p90 of 3")
+}
+
+func TestStreamingPercentileEstimates(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	uniform := make([]float64, 100_000)
+	latencies := make([]float64, len(uniform))
+	for i := range uniform {
+		uniform[i] = rng.Float64() * 1000
+		latencies[i] = rng.ExpFloat64() * 20 // Long tailed, like request latencies in ms
+	}
+
+	for _, p := range []float64{1, 25, 50, 90, 99} {
+		for name, xs := range map[string][]float64{"uniform": uniform, "latency": latencies} {
+			s := NewStreamingPercentile[float64](p)
+			for _, x := range xs {
+				s.Add(x)
+			}
+			exact := Percentile(xs, p)
+			ExpectedAlmostEqual(t, exact, s.Value(), 0.5, 0.02, name+" p"+strconv.FormatFloat(p, 'g', -1, 64))
+		}
+	}
+}
+
+func TestStreamingPercentileSorted(t *testing.T) {
+	// Sorted input is a worst case for P², since every value lands in the max cell.
+	s := NewStreamingPercentile[int](50)
+	for i := range 10_001 {
+		s.Add(i)
+	}
+	ExpectedAlmostEqual(t, 5000, s.Value(), 0, 0.01, "sorted median")
+}
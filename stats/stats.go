@@ -0,0 +1,190 @@
+// Package stats contains simple statistics over slices of numbers, as well as streaming versions for when you can't (or
+// don't want to) keep every value around. eg, summarizing benchmark runs, game telemetry, or service latencies.
+//
+// Results are float64 regardless of the input type, since the mean of integers is rarely an integer.
+// NaN values are treated as missing and skipped everywhere, so one bad reading doesn't poison a whole summary. A result
+// is only NaN when there's nothing left to summarize, eg an empty slice or one that's all NaNs.
+// Nothing in here is safe for concurrent use - wrap streaming types in a mutex if you need that.
+package stats
+
+import (
+	"math"
+	"slices"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// Sum returns the sum of xs. For floats this uses Neumaier's improved Kahan summation in a float64, so adding many small
+// values to a large one doesn't lose them to rounding. NaNs are skipped. Integers are summed normally, and wrap on
+// overflow.
+func Sum[N num.Real](xs []N) N {
+	if !num.IsFloat[N]() {
+		var sum N
+		for _, x := range xs {
+			sum += x
+		}
+		return sum
+	}
+	return N(compensatedSum(xs))
+}
+
+// Mean returns the arithmetic mean of xs, or NaN if xs is empty.
+func Mean[N num.Real](xs []N) float64 {
+	xs = withoutNaN(xs)
+	if len(xs) == 0 {
+		return math.NaN()
+	}
+	// Summing as floats means integers can't overflow.
+	return compensatedSum(xs) / float64(len(xs))
+}
+
+// Variance returns the population variance of xs, or NaN if xs is empty. Use SampleVariance if xs is a sample
+// of a larger population.
+func Variance[N num.Real](xs []N) float64 {
+	var acc Accumulator[N]
+	acc.AddAll(xs)
+	return acc.Variance()
+}
+
+// SampleVariance returns the sample variance of xs (with Bessel's correction), or NaN if xs has fewer than 2 values.
+func SampleVariance[N num.Real](xs []N) float64 {
+	var acc Accumulator[N]
+	acc.AddAll(xs)
+	return acc.SampleVariance()
+}
+
+// StdDev returns the population standard deviation of xs, or NaN if xs is empty.
+func StdDev[N num.Real](xs []N) float64 {
+	return math.Sqrt(Variance(xs))
+}
+
+// Min returns the smallest value in xs, or NaN if every value is NaN. It panics if xs is empty.
+func Min[N num.Real](xs []N) N {
+	if len(xs) == 0 {
+		panic("stats: Min of an empty slice")
+	}
+	return xs[ArgMin(xs)]
+}
+
+// Max returns the largest value in xs, or NaN if every value is NaN. It panics if xs is empty.
+func Max[N num.Real](xs []N) N {
+	if len(xs) == 0 {
+		panic("stats: Max of an empty slice")
+	}
+	return xs[ArgMax(xs)]
+}
+
+// ArgMin returns the index of the first smallest value in xs, or -1 if xs is empty.
+// If every value is NaN, this is the index of the first one.
+func ArgMin[N num.Real](xs []N) int {
+	best := -1
+	for i, x := range xs {
+		// x != x is only true for NaN, so this skips NaNs while replacing a NaN best with the first real value.
+		if best < 0 || (x == x && (x < xs[best] || xs[best] != xs[best])) {
+			best = i
+		}
+	}
+	return best
+}
+
+// ArgMax returns the index of the first largest value in xs, or -1 if xs is empty.
+// If every value is NaN, this is the index of the first one.
+func ArgMax[N num.Real](xs []N) int {
+	best := -1
+	for i, x := range xs {
+		if best < 0 || (x == x && (x > xs[best] || xs[best] != xs[best])) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Median returns the middle value of xs, or the mean of the two middle values if len(xs) is even.
+// Returns NaN if xs is empty. xs is not modified.
+func Median[N num.Real](xs []N) float64 {
+	return Percentile(xs, 50)
+}
+
+// Percentile returns the p-th percentile of xs, where p is between 0 and 100. This interpolates linearly between the
+// closest values, which is the same as the default for NumPy, Excel's PERCENTILE.INC, and R's type 7.
+// p outside of that is clamped to it, and a NaN p panics. Returns NaN if xs is empty. xs is not modified.
+func Percentile[N num.Real](xs []N, p float64) float64 {
+	return Percentiles(xs, p)[0]
+}
+
+// Percentiles is like Percentile, but only sorts xs once for all the given percentiles.
+// This is the one you want for summaries like p50/p90/p99.
+func Percentiles[N num.Real](xs []N, ps ...float64) []float64 {
+	sorted := slices.Clone(withoutNaN(xs))
+	slices.Sort(sorted)
+
+	out := make([]float64, len(ps))
+	for i, p := range ps {
+		out[i] = sortedPercentile(sorted, p)
+	}
+	return out
+}
+
+func sortedPercentile[N num.Real](sorted []N, p float64) float64 {
+	checkPercentile(p)
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	rank := min(max(p, 0), 100) / 100 * float64(len(sorted)-1)
+	lo := int(rank)
+	if lo >= len(sorted)-1 {
+		return float64(sorted[len(sorted)-1])
+	}
+	frac := rank - float64(lo)
+	return float64(sorted[lo]) + frac*(float64(sorted[lo+1])-float64(sorted[lo]))
+}
+
+// checkPercentile panics if p is NaN, which can't be clamped to [0, 100] like other out of range percentiles.
+func checkPercentile(p float64) {
+	if math.IsNaN(p) {
+		panic("stats: percentile p is NaN")
+	}
+}
+
+// withoutNaN returns xs with any NaNs removed. It only copies xs if there's a NaN to remove.
+func withoutNaN[N num.Real](xs []N) []N {
+	first := slices.IndexFunc(xs, isNaN)
+	if first < 0 {
+		return xs
+	}
+	out := slices.Clone(xs[:first])
+	for _, x := range xs[first+1:] {
+		if !isNaN(x) {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// isNaN is math.IsNaN for any number type. Only NaN isn't equal to itself, and integers never are.
+func isNaN[N num.Real](x N) bool {
+	return x != x
+}
+
+// compensatedSum adds xs as float64s using Neumaier's improved Kahan summation, which tracks the low-order digits lost
+// by each addition and adds them back at the end. NaNs are skipped.
+func compensatedSum[N num.Real](xs []N) float64 {
+	var sum, c float64
+	for _, x := range xs {
+		if isNaN(x) {
+			continue
+		}
+		f := float64(x)
+		t := sum + f
+		if math.Abs(sum) >= math.Abs(f) {
+			c += (sum - t) + f // Low-order digits of f were lost
+		} else {
+			c += (f - t) + sum // Low-order digits of sum were lost
+		}
+		sum = t
+	}
+	if math.IsInf(sum, 0) {
+		return sum // The compensation would be Inf-Inf, which is NaN
+	}
+	return sum + c
+}
@@ -0,0 +1,142 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestSum(t *testing.T) {
+	ExpectedActual(t, 15, Sum([]int{1, 2, 3, 4, 5}), "int")
+	ExpectedActual(t, uint8(4), Sum([]uint8{255, 5}), "uint8 wraps")
+	ExpectedActual(t, 0, Sum([]int{}), "empty")
+
+	// A naive sum loses every 1 to rounding, since 1e16+1 isn't representable.
+	xs := []float64{1e16}
+	for range 1000 {
+		xs = append(xs, 1)
+	}
+	xs = append(xs, -1e16)
+	ExpectedActual(t, 1000.0, Sum(xs), "compensated")
+
+	tenths := make([]float32, 1_000_000)
+	for i := range tenths {
+		tenths[i] = 0.1
+	}
+	ExpectedActual(t, float32(100000.0015), Sum(tenths), "float32 accumulates in float64")
+	ExpectedActual(t, math.Inf(1), Sum([]float64{math.Inf(1), 1}), "inf")
+	ExpectedActual(t, 1.0, Sum([]float64{1, math.NaN()}), "nan is skipped")
+}
+
+// NaNs are treated as missing everywhere, so every summary of the same values gives the same answer with them mixed in.
+func TestNaNSkipped(t *testing.T) {
+	nan := math.NaN()
+	xs := []float64{nan, 1, 2, nan, 3}
+	clean := []float64{1, 2, 3}
+
+	ExpectedActual(t, Sum(clean), Sum(xs), "sum")
+	ExpectedActual(t, Mean(clean), Mean(xs), "mean")
+	ExpectedActual(t, Variance(clean), Variance(xs), "variance")
+	ExpectedActual(t, 1.0, Min(xs), "min")
+	ExpectedActual(t, 3.0, Max(xs), "max")
+	ExpectedActual(t, Percentiles(clean, 0, 50, 100), Percentiles(xs, 0, 50, 100), "percentiles")
+
+	var acc Accumulator[float64]
+	acc.AddAll(xs)
+	ExpectedActual(t, 3, acc.Count(), "accumulator count")
+	ExpectedActual(t, 1.0, acc.Min(), "accumulator min")
+	ExpectedActual(t, 3.0, acc.Max(), "accumulator max")
+	ExpectedActual(t, 2.0, acc.Mean(), "accumulator mean")
+
+	ema := NewEMA[float64](0.5)
+	ExpectedActual(t, true, math.IsNaN(ema.Add(nan)), "ema nothing yet")
+	ema.Add(2)
+	ExpectedActual(t, 2.0, ema.Add(nan), "ema skips nan")
+
+	sp := NewStreamingPercentile[float64](50)
+	for range 10 {
+		for _, x := range xs {
+			sp.Add(x)
+		}
+	}
+	ExpectedActual(t, 30, sp.Count(), "streaming count")
+	ExpectedActual(t, false, math.IsNaN(sp.Value()), "streaming estimate")
+
+	h := NewHistogram(1.0, 2)
+	for _, x := range xs {
+		h.Add(x)
+	}
+	ExpectedActual(t, 3, h.Total(), "histogram total")
+
+	allNaN := []float64{nan, nan}
+	ExpectedActual(t, true, math.IsNaN(Mean(allNaN)), "all nan mean")
+	ExpectedActual(t, true, math.IsNaN(Min(allNaN)), "all nan min")
+	ExpectedActual(t, true, math.IsNaN(Median(allNaN)), "all nan median")
+}
+
+func TestMean(t *testing.T) {
+	ExpectedActual(t, 2.5, Mean([]int{1, 2, 3, 4}), "int isn't truncated")
+	ExpectedActual(t, 127.5, Mean([]uint8{255, 0}), "int doesn't overflow")
+	ExpectedActual(t, float64(math.MaxInt64), Mean([]int64{math.MaxInt64, math.MaxInt64}), "int64 doesn't overflow")
+	ExpectedActual(t, true, math.IsNaN(Mean([]float64{})), "empty")
+}
+
+func TestVariance(t *testing.T) {
+	xs := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	ExpectedActual(t, 4.0, Variance(xs), "population")
+	ExpectedActual(t, 2.0, StdDev(xs), "stddev")
+	ExpectedApprox(t, 32.0/7, SampleVariance(xs), 1e-12, "sample")
+	ExpectedActual(t, true, math.IsNaN(SampleVariance([]int{1})), "sample of 1")
+
+	// Large values that are close together cancel catastrophically with the sum of squares formula.
+	shifted := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	ExpectedActual(t, 22.5, Variance(shifted), "shifted")
+}
+
+func TestMinMax(t *testing.T) {
+	xs := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	ExpectedActual(t, 1, Min(xs), "min")
+	ExpectedActual(t, 9, Max(xs), "max")
+	ExpectedActual(t, 1, ArgMin(xs), "argmin is the first")
+	ExpectedActual(t, 5, ArgMax(xs), "argmax")
+	ExpectedActual(t, -1, ArgMin([]int{}), "argmin empty")
+	ExpectedActual(t, -1, ArgMax([]float32{}), "argmax empty")
+
+	nan := math.NaN()
+	fs := []float64{nan, 2, nan, -1, 3, nan}
+	ExpectedActual(t, 3, ArgMin(fs), "argmin skips nan")
+	ExpectedActual(t, 4, ArgMax(fs), "argmax skips nan")
+	ExpectedActual(t, 0, ArgMin([]float64{nan, nan}), "all nan")
+
+	defer func() {
+		ExpectedActual(t, "stats: Min of an empty slice", recover(), "min empty panics")
+	}()
+	Min([]int{})
+}
+
+func TestPercentile(t *testing.T) {
+	xs := []int{15, 20, 35, 40, 50}
+	ExpectedActual(t, 35.0, Median(xs), "odd median")
+	ExpectedActual(t, 27.5, Median([]int{40, 15, 35, 20}), "even median")
+	ExpectedActual(t, []int{15, 20, 35, 40, 50}, xs, "input isn't modified")
+
+	ExpectedActual(t, []float64{15, 20, 29, 46, 50, 15, 50}, Percentiles(xs, 0, 25, 40, 90, 100, -5, 200), "percentiles")
+	ExpectedActual(t, 7.0, Percentile([]float64{7}, 99), "single")
+	ExpectedActual(t, true, math.IsNaN(Median([]float64{})), "empty")
+
+	h := NewHistogram(10, 20)
+	h.Add(5)
+	for name, f := range map[string]func(){
+		"percentile": func() { Percentile(xs, math.NaN()) },
+		"empty":      func() { Percentile([]int{}, math.NaN()) },
+		"histogram":  func() { h.Percentile(math.NaN()) },
+	} {
+		func() {
+			defer func() {
+				ExpectedActual(t, "stats: percentile p is NaN", recover(), name+" nan p panics")
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,88 @@
+package uuid
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/entropy"
+)
+
+func TestBuffered(t *testing.T) {
+	gen := NewBuffered(nil, 4)
+	seen := map[RandomID]bool{}
+	for range 100 {
+		seen[gen.NewRandom()] = true
+	}
+	ExpectedActual(t, 100, len(seen), "unique across refills")
+
+	id := gen.NewV4()
+	ExpectedActual(t, 4, id.Version(), "version")
+	ExpectedActual(t, true, id.IsRFC(), "variant")
+}
+
+func TestIDBuffer(t *testing.T) {
+	r := entropy.Seeded(1)
+	expected := NewGenerator(entropy.Seeded(1))
+	buf := &idBuffer{buf: make([]byte, 32), pos: 32}
+
+	ExpectedActual(t, expected.NewRandom(), buf.next(r), "first comes from the reader")
+	ExpectedActual(t, make([]byte, 16), buf.buf[:16], "used bytes are cleared")
+	ExpectedActual(t, false, RandomID(buf.buf[16:]).IsZero(), "unused bytes are kept")
+	ExpectedActual(t, expected.NewRandom(), buf.next(r), "second")
+	ExpectedActual(t, make([]byte, 32), buf.buf, "all cleared")
+	ExpectedActual(t, expected.NewRandom(), buf.next(r), "refilled")
+}
+
+func TestBufferedConcurrent(t *testing.T) {
+	const workers, perWorker = 16, 1000
+	gen := NewBuffered(nil, 0)
+	results := make([][]RandomID, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for range perWorker {
+				results[w] = append(results[w], gen.NewRandom())
+			}
+		})
+	}
+	wg.Wait()
+
+	seen := map[RandomID]bool{}
+	for _, ids := range results {
+		for _, id := range ids {
+			seen[id] = true
+		}
+	}
+	ExpectedActual(t, workers*perWorker, len(seen), "unique")
+}
+
+func BenchmarkNewRandom(b *testing.B) {
+	for b.Loop() {
+		NewRandom()
+	}
+}
+
+func BenchmarkBuffered(b *testing.B) {
+	gen := NewBuffered(nil, 0)
+	for b.Loop() {
+		gen.NewRandom()
+	}
+}
+
+func BenchmarkNewRandomParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			NewRandom()
+		}
+	})
+}
+
+func BenchmarkBufferedParallel(b *testing.B) {
+	gen := NewBuffered(nil, 0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			gen.NewRandom()
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package uuid
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// base58Alphabet is the Bitcoin base58 alphabet, which skips 0, O, I, and l. It's in ASCII order.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58MaxLen is the most characters 128 bits can take, which is ceil(128 / log2(58)).
+const base58MaxLen = 22
+
+var base58Values = func() [256]byte {
+	var values [256]byte
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i := range len(base58Alphabet) {
+		values[base58Alphabet[i]] = byte(i)
+	}
+	return values
+}()
+
+// base64Len is the number of unpadded base64 characters needed for 16 bytes.
+const base64Len = 22
+
+// Base32 returns the ID as 26 characters of Crockford's base32, eg "7ZZZZZZZZZZZZZZZZZZZZZZZZZ".
+// This is the same as ULID, and sorts in the same order as the bytes.
+func (r RandomID) Base32() string {
+	return r.ULID()
+}
+
+// ParseBase32 parses an ID from Crockford's base32. It's forgiving of the mistakes people make copying IDs by hand:
+// case is ignored, dashes are skipped, and I and L are read as 1 and O as 0.
+func ParseBase32(s string) (RandomID, error) {
+	text := strings.ReplaceAll(s, "-", "")
+	if len(text) != crockfordLen {
+		return RandomID{}, fmt.Errorf("%w: base32 must be %d characters without dashes, got %d in %q", ErrInvalidID, crockfordLen, len(text), s)
+	}
+	return decodeCrockford(text, &crockfordLenientValues)
+}
+
+// Base58 returns the ID in base58 with the Bitcoin alphabet, which is at most 22 characters and has no punctuation or
+// look-alike characters, so it's easy to double-click and copy. As is standard for base58, each leading zero byte is
+// written as a "1", so the length varies.
+func (r RandomID) Base58() string {
+	var buf [base58MaxLen]byte
+	i := len(buf)
+
+	hi, lo := r.halves()
+	for hi != 0 || lo != 0 {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, 58)
+		lo, rem = bits.Div64(rem, lo, 58)
+		i--
+		buf[i] = base58Alphabet[rem]
+	}
+	for _, b := range r {
+		if b != 0 {
+			break
+		}
+		i--
+		buf[i] = base58Alphabet[0]
+	}
+	return string(buf[i:])
+}
+
+// ParseBase58 parses an ID from base58 with the Bitcoin alphabet, as returned by Base58.
+func ParseBase58(s string) (RandomID, error) {
+	if len(s) == 0 || len(s) > base58MaxLen {
+		return RandomID{}, fmt.Errorf("%w: base58 must be 1 to %d characters, got %d", ErrInvalidID, base58MaxLen, len(s))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	var hi, lo uint64
+	for i := zeros; i < len(s); i++ {
+		v := base58Values[s[i]]
+		if v == 0xff {
+			return RandomID{}, fmt.Errorf("%w: invalid base58 character %q at position %d", ErrInvalidID, s[i], i)
+		}
+		// (hi, lo) = (hi, lo)*58 + v, checking that it still fits in 128 bits.
+		carry, newLo := bits.Mul64(lo, 58)
+		newLo, c := bits.Add64(newLo, uint64(v), 0)
+		over, newHi := bits.Mul64(hi, 58)
+		newHi, c = bits.Add64(newHi, carry, c)
+		if over != 0 || c != 0 {
+			return RandomID{}, fmt.Errorf("%w: base58 value is more than 128 bits", ErrInvalidID)
+		}
+		hi, lo = newHi, newLo
+	}
+
+	id := fromHalves(hi, lo)
+	// Each leading "1" stands for exactly one leading zero byte, so the number must fill the rest of the ID.
+	if actualZeros := leadingZeroBytes(id); actualZeros != zeros {
+		return RandomID{}, fmt.Errorf("%w: base58 is %d bytes, expected %d", ErrInvalidID, zeros+len(id)-actualZeros, len(id))
+	}
+	return id, nil
+}
+
+// Base64URL returns the ID as 22 characters of unpadded URL-safe base64, which is the shortest of the encodings.
+func (r RandomID) Base64URL() string {
+	return base64.RawURLEncoding.EncodeToString(r[:])
+}
+
+// ParseBase64URL parses an ID from unpadded URL-safe base64, as returned by Base64URL.
+func ParseBase64URL(s string) (RandomID, error) {
+	var id RandomID
+	if len(s) != base64Len {
+		return id, fmt.Errorf("%w: base64 must be %d characters, got %d", ErrInvalidID, base64Len, len(s))
+	}
+	if _, err := base64.RawURLEncoding.Strict().Decode(id[:], []byte(s)); err != nil {
+		return RandomID{}, fmt.Errorf("%w: %w", ErrInvalidID, err)
+	}
+	return id, nil
+}
+
+// halves returns the ID as two big-endian 64-bit numbers.
+func (r RandomID) halves() (hi, lo uint64) {
+	return binary.BigEndian.Uint64(r[:8]), binary.BigEndian.Uint64(r[8:])
+}
+
+// fromHalves is the inverse of halves.
+func fromHalves(hi, lo uint64) RandomID {
+	var id RandomID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id
+}
+
+func leadingZeroBytes(id RandomID) int {
+	for i, b := range id {
+		if b != 0 {
+			return i
+		}
+	}
+	return len(id)
+}
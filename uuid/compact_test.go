@@ -0,0 +1,159 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+var (
+	maxID   = RandomID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	smallID = RandomID{15: 1}
+)
+
+func TestBase32(t *testing.T) {
+	id := MustParse(testCanonical)
+	s := id.Base32()
+	ExpectedActual(t, "7R3N7TWZFC278AES80M34HWTZP", s, "encode")
+	for _, in := range []string{s, strings.ToLower(s), "7R3N-7TWZ-FC27-8AES-8OM3-4HWT-ZP"} {
+		back, err := ParseBase32(in)
+		ExpectedActual(t, nil, err, in+" error")
+		ExpectedActual(t, id, back, in)
+	}
+	ExpectedActual(t, "00000000000000000000000001", smallID.Base32(), "small")
+	for _, in := range []string{"OOOOOOOOOOOOOOOOOOOOOOOOOI", "oooooooooooooooooooooooool", "00000-00000-00000-00000-00000L"} {
+		back, err := ParseBase32(in)
+		ExpectedActual(t, nil, err, in+" error")
+		ExpectedActual(t, smallID, back, in)
+	}
+
+	_, err := ParseBase32("7R3N7TWZFC278AES80M34HWTZU")
+	ExpectedActual(t, `invalid ID: invalid base32 character 'U' at position 25`, err.Error(), "bad character")
+	_, err = ParseBase32("8R3N7TWZFC278AES80M34HWTZP")
+	ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "too large")
+	_, err = ParseBase32("7R3N7TYZ")
+	ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "too short")
+}
+
+func TestBase58(t *testing.T) {
+	c := []struct {
+		In       RandomID
+		Expected string
+	}{
+		{Nil, "1111111111111111"},
+		{smallID, "1111111111111112"},
+		{RandomID{15: 58}, "11111111111111121"},
+		{maxID, "YcVfxkQb6JRzqk5kF2tNLv"},
+		{MustParse(testCanonical), "Xe22UfxT3rxcKJEAfL5373"},
+	}
+	for _, tc := range c {
+		s := tc.In.Base58()
+		ExpectedActual(t, tc.Expected, s, "encode "+tc.In.String())
+		back, err := ParseBase58(s)
+		ExpectedActual(t, nil, err, "decode error "+s)
+		ExpectedActual(t, tc.In, back, "decode "+s)
+	}
+
+	for _, bad := range []struct{ In, Err string }{
+		{"", "invalid ID: base58 must be 1 to 22 characters, got 0"},
+		{"Xe22UfxT3rxcKJEAfL537O", `invalid ID: invalid base58 character 'O' at position 21`},
+		{"zzzzzzzzzzzzzzzzzzzzzz", "invalid ID: base58 value is more than 128 bits"},
+		{"2", "invalid ID: base58 is 1 bytes, expected 16"},
+		{"11111111111111111", "invalid ID: base58 is 17 bytes, expected 16"},
+		{"11YcVfxkQb6JRzqk5kF2tN", "invalid ID: base58 is 17 bytes, expected 16"},
+	} {
+		_, err := ParseBase58(bad.In)
+		ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "is invalid "+bad.In)
+		ExpectedActual(t, bad.Err, err.Error(), "error "+bad.In)
+	}
+}
+
+func TestBase64URL(t *testing.T) {
+	id := MustParse(testCanonical)
+	s := id.Base64URL()
+	ExpectedActual(t, "-B1Prn3sEdCnZQCgyR5r9g", s, "encode")
+	back, err := ParseBase64URL(s)
+	ExpectedActual(t, nil, err, "decode error")
+	ExpectedActual(t, id, back, "decode")
+	ExpectedActual(t, "_____________________w", maxID.Base64URL(), "max")
+
+	for _, bad := range []string{"", "-B1Prn3sEdCnZQCgyR5r9g==", "-B1Prn3sEdCnZQCgyR5r9/", "-B1Prn3sEdCnZQCgyR5r9h"} {
+		_, err := ParseBase64URL(bad)
+		ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "invalid "+bad)
+	}
+}
+
+func TestCompactRoundTrips(t *testing.T) {
+	for range 1000 {
+		id := NewRandom()
+		b32, err32 := ParseBase32(id.Base32())
+		b58, err58 := ParseBase58(id.Base58())
+		b64, err64 := ParseBase64URL(id.Base64URL())
+		if !ExpectedActual(t, []error{nil, nil, nil}, []error{err32, err58, err64}, "errors") ||
+			!ExpectedActual(t, []RandomID{id, id, id}, []RandomID{b32, b58, b64}, "round trip") {
+			return
+		}
+	}
+}
+
+var benchID = MustParse(testCanonical)
+
+func BenchmarkEncodeHex(b *testing.B) {
+	for b.Loop() {
+		_ = benchID.String()
+	}
+}
+
+func BenchmarkEncodeCanonical(b *testing.B) {
+	for b.Loop() {
+		_ = benchID.Canonical()
+	}
+}
+
+func BenchmarkEncodeBase32(b *testing.B) {
+	for b.Loop() {
+		_ = benchID.Base32()
+	}
+}
+
+func BenchmarkEncodeBase58(b *testing.B) {
+	for b.Loop() {
+		_ = benchID.Base58()
+	}
+}
+
+func BenchmarkEncodeBase64URL(b *testing.B) {
+	for b.Loop() {
+		_ = benchID.Base64URL()
+	}
+}
+
+func BenchmarkParseCanonical(b *testing.B) {
+	s := benchID.Canonical()
+	for b.Loop() {
+		_, _ = Parse(s)
+	}
+}
+
+func BenchmarkParseBase32(b *testing.B) {
+	s := benchID.Base32()
+	for b.Loop() {
+		_, _ = ParseBase32(s)
+	}
+}
+
+func BenchmarkParseBase58(b *testing.B) {
+	s := benchID.Base58()
+	for b.Loop() {
+		_, _ = ParseBase58(s)
+	}
+}
+
+func BenchmarkParseBase64URL(b *testing.B) {
+	s := benchID.Base64URL()
+	for b.Loop() {
+		_, _ = ParseBase64URL(s)
+	}
+}
@@ -0,0 +1,62 @@
+package uuid
+
+import "fmt"
+
+// crockfordAlphabet is Crockford's base32 alphabet, which skips I, L, O, and U to avoid confusion with 1, 0, and V.
+// It's in ASCII order, so encoded strings sort the same as the bytes they came from.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// crockfordLen is the number of characters needed for 128 bits, at 5 bits each. The 2 spare bits are at the start,
+// so the first character is always 0-7.
+const crockfordLen = 26
+
+// crockfordValues maps each character to its value, or 0xff if it's invalid. Lowercase is accepted too.
+var crockfordValues = func() [256]byte {
+	var values [256]byte
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i := range len(crockfordAlphabet) {
+		c := crockfordAlphabet[i]
+		values[c] = byte(i)
+		values[c|0x20] = byte(i) // Lowercase, which is harmless for the digits as they're already 0x3_
+	}
+	return values
+}()
+
+// crockfordLenientValues is crockfordValues plus the characters people confuse with digits: I and L for 1, and O for 0.
+var crockfordLenientValues = func() [256]byte {
+	values := crockfordValues
+	for _, c := range "IiLl" {
+		values[c] = 1
+	}
+	values['O'], values['o'] = 0, 0
+	return values
+}()
+
+// encodeCrockford encodes the 128 bits of id as 26 base32 characters, most significant first.
+func encodeCrockford(dst *[crockfordLen]byte, id RandomID) {
+	hi, lo := id.halves()
+	for i := crockfordLen - 1; i >= 0; i-- {
+		dst[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+}
+
+// decodeCrockford decodes 26 base32 characters using the given character values. s must already be the right length.
+func decodeCrockford(s string, values *[256]byte) (RandomID, error) {
+	var hi, lo uint64
+	for i := range crockfordLen {
+		v := values[s[i]]
+		if v == 0xff {
+			return RandomID{}, fmt.Errorf("%w: invalid base32 character %q at position %d", ErrInvalidID, s[i], i)
+		}
+		if i == 0 && v > 7 {
+			return RandomID{}, fmt.Errorf("%w: base32 value is more than 128 bits, first character must be 0-7", ErrInvalidID)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	return fromHalves(hi, lo), nil
+}
@@ -0,0 +1,68 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Nil is the zero ID, with every bit set to 0.
+var Nil RandomID
+
+// IsZero returns true if r is the zero ID, which is usually a sign it was never set.
+// This also makes the `json:",omitzero"` option work as expected.
+func (r RandomID) IsZero() bool {
+	return r == Nil
+}
+
+// MarshalText implements encoding.TextMarshaler, using the Canonical form. This is used by JSON and TOML.
+func (r RandomID) MarshalText() ([]byte, error) {
+	return []byte(r.Canonical()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any form that Parse does.
+func (r *RandomID) UnmarshalText(text []byte) error {
+	id, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*r = id
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the 16 raw bytes.
+func (r RandomID) MarshalBinary() ([]byte, error) {
+	return r[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, which requires exactly 16 bytes.
+func (r *RandomID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(r) {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidID, len(r), len(data))
+	}
+	copy(r[:], data)
+	return nil
+}
+
+// Value implements driver.Valuer, storing the ID in its Canonical form. This works for both uuid and text columns.
+// Use MarshalBinary if you'd rather store the 16 raw bytes.
+func (r RandomID) Value() (driver.Value, error) {
+	return r.Canonical(), nil
+}
+
+// Scan implements sql.Scanner. It accepts the 16 raw bytes, or any text form that Parse does as a string or []byte.
+// A NULL becomes Nil.
+func (r *RandomID) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*r = Nil
+		return nil
+	case string:
+		return r.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == len(r) {
+			return r.UnmarshalBinary(v)
+		}
+		return r.UnmarshalText(v)
+	}
+	return fmt.Errorf("%w: can't scan %T", ErrInvalidID, src)
+}
@@ -0,0 +1,112 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/fileload"
+)
+
+var (
+	_ encoding.TextMarshaler     = RandomID{}
+	_ encoding.TextUnmarshaler   = (*RandomID)(nil)
+	_ encoding.BinaryMarshaler   = RandomID{}
+	_ encoding.BinaryUnmarshaler = (*RandomID)(nil)
+	_ driver.Valuer              = RandomID{}
+	_ sql.Scanner                = (*RandomID)(nil)
+)
+
+const testCanonical = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+
+func TestIsZero(t *testing.T) {
+	ExpectedActual(t, true, Nil.IsZero(), "nil")
+	ExpectedActual(t, true, RandomID{}.IsZero(), "zero value")
+	ExpectedActual(t, false, NewRandom().IsZero(), "random")
+}
+
+func TestTextAndBinary(t *testing.T) {
+	id := NewV4()
+	text, err := id.MarshalText()
+	ExpectedActual(t, nil, err, "marshal text error")
+	ExpectedActual(t, id.Canonical(), string(text), "text is canonical")
+	var back RandomID
+	ExpectedActual(t, nil, back.UnmarshalText(text), "unmarshal text error")
+	ExpectedActual(t, id, back, "text round trip")
+	ExpectedActual(t, true, errors.Is(back.UnmarshalText([]byte("nope")), ErrInvalidID), "bad text")
+
+	data, err := id.MarshalBinary()
+	ExpectedActual(t, nil, err, "marshal binary error")
+	ExpectedActual(t, id[:], data, "binary is raw bytes")
+	back = Nil
+	ExpectedActual(t, nil, back.UnmarshalBinary(data), "unmarshal binary error")
+	ExpectedActual(t, id, back, "binary round trip")
+	ExpectedActual(t, true, errors.Is(back.UnmarshalBinary(data[:15]), ErrInvalidID), "short binary")
+}
+
+type user struct {
+	ID     RandomID
+	Parent RandomID `json:",omitzero"`
+}
+
+func TestJSON(t *testing.T) {
+	u := user{ID: MustParse(testCanonical)}
+	data, err := json.Marshal(u)
+	ExpectedActual(t, nil, err, "marshal error")
+	ExpectedActual(t, `{"ID":"`+testCanonical+`"}`, string(data), "marshal")
+
+	var back user
+	ExpectedActual(t, nil, json.Unmarshal(data, &back), "unmarshal error")
+	ExpectedActual(t, u, back, "round trip")
+	ExpectedActual(t, nil, json.Unmarshal([]byte(`{"ID":"f81d4fae7dec11d0a76500a0c91e6bf6"}`), &back), "hex error")
+	ExpectedActual(t, u, back, "hex")
+	ExpectedActual(t, true, errors.Is(json.Unmarshal([]byte(`{"ID":"f81d"}`), &back), ErrInvalidID), "invalid")
+}
+
+func TestConfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	tomlFile := filepath.Join(dir, "cfg.toml")
+	jsonFile := filepath.Join(dir, "cfg.json")
+	ExpectedActual(t, nil, os.WriteFile(tomlFile, []byte(`ID = "urn:uuid:`+testCanonical+`"`), 0o600), "writing toml")
+	ExpectedActual(t, nil, os.WriteFile(jsonFile, []byte(`{"ID": "{`+testCanonical+`}"}`), 0o600), "writing json")
+	expected := user{ID: MustParse(testCanonical)}
+
+	cfg, _, err := fileload.TOML[user](tomlFile)
+	ExpectedActual(t, nil, err, "loading toml")
+	ExpectedActual(t, expected, *cfg, "toml")
+
+	cfg, err = fileload.JSON[user](jsonFile)
+	ExpectedActual(t, nil, err, "loading json")
+	ExpectedActual(t, expected, *cfg, "json")
+}
+
+func TestSQL(t *testing.T) {
+	id := MustParse(testCanonical)
+	v, err := id.Value()
+	ExpectedActual(t, nil, err, "value error")
+	ExpectedActual(t, driver.Value(testCanonical), v, "value")
+
+	for name, src := range map[string]any{
+		"string":      testCanonical,
+		"text bytes":  []byte(testCanonical),
+		"hex":         "f81d4fae7dec11d0a76500a0c91e6bf6",
+		"raw bytes":   id[:],
+		"driver form": v,
+	} {
+		var back RandomID
+		ExpectedActual(t, nil, back.Scan(src), name+" error")
+		ExpectedActual(t, id, back, name)
+	}
+
+	back := id
+	ExpectedActual(t, nil, back.Scan(nil), "null error")
+	ExpectedActual(t, Nil, back, "null")
+	ExpectedActual(t, true, errors.Is(back.Scan(42), ErrInvalidID), "wrong type")
+	ExpectedActual(t, true, errors.Is(back.Scan([]byte{1, 2, 3}), ErrInvalidID), "wrong length")
+}
@@ -0,0 +1,115 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidID is returned (wrapped) when an ID can't be parsed.
+var ErrInvalidID = errors.New("invalid ID")
+
+const (
+	hexLen    = 32
+	dashedLen = 36
+	urnPrefix = "urn:uuid:"
+)
+
+// Positions of the dashes in the canonical 8-4-4-4-12 form.
+var dashPositions = [4]int{8, 13, 18, 23}
+
+// NewV4 returns a new random ID with the RFC 9562 version 4 and variant bits set, leaving 122 random bits.
+// Use this instead of NewRandom when the ID will be stored or checked by something that expects a real UUID,
+// such as a Postgres uuid column.
+func NewV4() RandomID {
+	id := NewRandom()
+	id.setVersion(4)
+	return id
+}
+
+// Version returns the RFC 9562 version of the ID, eg 4 for a NewV4 ID. IDs from NewRandom have a random version,
+// so this is only meaningful for IDs with the RFC variant - see IsRFC.
+func (r RandomID) Version() int {
+	return int(r[6] >> 4)
+}
+
+// IsRFC returns true if the ID has the RFC 9562 variant bits (0b10) set.
+func (r RandomID) IsRFC() bool {
+	return r[8]&0xc0 == 0x80
+}
+
+// setVersion sets the version in the top 4 bits of byte 6, and the RFC variant in the top 2 bits of byte 8.
+func (r *RandomID) setVersion(v byte) {
+	r[6] = r[6]&0x0f | v<<4
+	r[8] = r[8]&0x3f | 0x80
+}
+
+// Canonical returns the ID in the standard dashed UUID form, eg "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func (r RandomID) Canonical() string {
+	var buf [dashedLen]byte
+	hex.Encode(buf[0:8], r[0:4])
+	hex.Encode(buf[9:13], r[4:6])
+	hex.Encode(buf[14:18], r[6:8])
+	hex.Encode(buf[19:23], r[8:10])
+	hex.Encode(buf[24:], r[10:])
+	for _, i := range dashPositions {
+		buf[i] = '-'
+	}
+	return string(buf[:])
+}
+
+// URN returns the ID as a URN, eg "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func (r RandomID) URN() string {
+	return urnPrefix + r.Canonical()
+}
+
+// Parse parses an ID in any of the common forms, in upper or lower case:
+//   - 32 hex characters, as returned by String: "f81d4fae7dec11d0a76500a0c91e6bf6"
+//   - Dashed, as returned by Canonical: "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+//   - Braced, as used by Microsoft: "{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}"
+//   - URN, as returned by URN: "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+//
+// The version and variant bits aren't checked, so any 128-bit value is accepted.
+func Parse(s string) (RandomID, error) {
+	var id RandomID
+	text := s
+	switch {
+	case len(text) >= len(urnPrefix) && strings.EqualFold(text[:len(urnPrefix)], urnPrefix):
+		text = text[len(urnPrefix):]
+	case strings.HasPrefix(text, "{"):
+		if !strings.HasSuffix(text, "}") {
+			return id, fmt.Errorf("%w: missing closing brace in %q", ErrInvalidID, s)
+		}
+		text = text[1 : len(text)-1]
+	}
+
+	switch len(text) {
+	case hexLen:
+		if _, err := hex.Decode(id[:], []byte(text)); err != nil {
+			return RandomID{}, fmt.Errorf("%w: %q: %w", ErrInvalidID, s, err)
+		}
+	case dashedLen:
+		for _, i := range dashPositions {
+			if text[i] != '-' {
+				return id, fmt.Errorf("%w: expected '-' at position %d in %q", ErrInvalidID, i, s)
+			}
+		}
+		stripped := text[0:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+		if _, err := hex.Decode(id[:], []byte(stripped)); err != nil {
+			return RandomID{}, fmt.Errorf("%w: %q: %w", ErrInvalidID, s, err)
+		}
+	default:
+		return id, fmt.Errorf("%w: expected %d or %d characters, got %d in %q", ErrInvalidID, hexLen, dashedLen, len(text), s)
+	}
+	return id, nil
+}
+
+// MustParse is like Parse, but panics if s can't be parsed. This is meant for IDs in code, like namespaces.
+func MustParse(s string) RandomID {
+	id, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
@@ -0,0 +1,57 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+func TestParse(t *testing.T) {
+	expected := RandomID{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}
+	for _, s := range []string{
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+		"F81D4FAE7DEC11D0A76500A0C91E6BF6",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+		"{f81d4fae7dec11d0a76500a0c91e6bf6}",
+		"urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"URN:UUID:F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+	} {
+		id, err := Parse(s)
+		ExpectedActual(t, nil, err, s+" error")
+		ExpectedActual(t, expected, id, s)
+	}
+
+	ExpectedActual(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", expected.Canonical(), "canonical")
+	ExpectedActual(t, "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", expected.URN(), "urn")
+	ExpectedActual(t, expected, MustParse(expected.String()), "must parse")
+
+	for _, bad := range []string{
+		"",
+		"f81d4fae7dec11d0a76500a0c91e6bf",
+		"f81d4fae-7dec-11d0-a765_00a0c91e6bf6",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bfg",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"urn:uuid:{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+		"f81d4fae-7dec11d0-a765-00a0c91e6bf6-",
+	} {
+		_, err := Parse(bad)
+		ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "invalid "+bad)
+	}
+}
+
+func TestV4(t *testing.T) {
+	for range 100 {
+		id := NewV4()
+		ExpectedActual(t, 4, id.Version(), "version")
+		ExpectedActual(t, true, id.IsRFC(), "variant")
+		s := id.Canonical()
+		ExpectedActual(t, byte('4'), s[14], "version character")
+		ExpectedActual(t, true, s[19] == '8' || s[19] == '9' || s[19] == 'a' || s[19] == 'b', "variant character")
+
+		back, err := Parse(s)
+		ExpectedActual(t, nil, err, "parse error")
+		ExpectedActual(t, id, back, "round trip")
+	}
+}
@@ -0,0 +1,55 @@
+package uuid
+
+import (
+	"io"
+	"time"
+
+	"github.com/seanpfeifer/rigging/entropy"
+)
+
+// Generator makes IDs using a chosen source of random bytes. This is mostly for tests, where entropy.Seeded gives the
+// same IDs every run - production code can stick with the package-level functions, which always use crypto/rand.
+//
+// The zero value uses crypto/rand. It's safe for concurrent use as long as the reader is, which both crypto/rand.Reader
+// and entropy.Seeded are.
+type Generator struct {
+	r io.Reader
+}
+
+// NewGenerator returns a generator that reads random bytes from r, or crypto/rand if r is nil.
+func NewGenerator(r io.Reader) *Generator {
+	return &Generator{r: r}
+}
+
+// NewRandom is like the package-level NewRandom, using g's random bytes.
+// It panics if the reader fails, as there's no sensible way to carry on without randomness.
+func (g *Generator) NewRandom() RandomID {
+	var id RandomID
+	g.read(id[:])
+	return id
+}
+
+// NewV4 is like the package-level NewV4, using g's random bytes.
+func (g *Generator) NewV4() RandomID {
+	id := g.NewRandom()
+	id.setVersion(4)
+	return id
+}
+
+// NewV7Generator is like the package-level NewV7Generator, using g's random bytes. With a fake clock and
+// entropy.Seeded, the IDs are the same every run.
+func (g *Generator) NewV7Generator(now func() time.Time) *V7Generator {
+	v7 := NewV7Generator(now)
+	v7.entropy = g.r
+	return v7
+}
+
+// read fills b from g's reader.
+func (g *Generator) read(b []byte) {
+	entropy.Read(g.r, b)
+}
+
+// NewIDFrom is like NewID, using g's random bytes.
+func NewIDFrom[T Prefixer](g *Generator) ID[T] {
+	return ID[T](g.NewRandom())
+}
@@ -0,0 +1,37 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/entropy"
+)
+
+func TestGeneratorDeterministic(t *testing.T) {
+	a, b := NewGenerator(entropy.Seeded(1)), NewGenerator(entropy.Seeded(1))
+	for range 10 {
+		ExpectedActual(t, a.NewRandom(), b.NewRandom(), "random")
+		ExpectedActual(t, a.NewV4(), b.NewV4(), "v4")
+		ExpectedActual(t, NewIDFrom[testUser](a), NewIDFrom[testUser](b), "prefixed")
+	}
+
+	// A snapshot of the first ID, which must never change for the same seed.
+	ExpectedActual(t, "6ae6783f-4fbd-491b-aeb8-8b73a48ed247", NewGenerator(entropy.Seeded(1)).NewV4().Canonical(), "snapshot")
+
+	now := func() time.Time { return time.UnixMilli(1700000000000) }
+	v7a := NewGenerator(entropy.Seeded(2)).NewV7Generator(now)
+	v7b := NewGenerator(entropy.Seeded(2)).NewV7Generator(now)
+	for range 10 {
+		ExpectedActual(t, v7a.New(), v7b.New(), "v7")
+	}
+
+	ExpectedActual(t, false, NewGenerator(entropy.Seeded(1)).NewRandom() == NewGenerator(entropy.Seeded(2)).NewRandom(), "different seeds")
+}
+
+func TestGeneratorDefault(t *testing.T) {
+	var zero Generator
+	ExpectedActual(t, false, zero.NewRandom() == zero.NewRandom(), "zero value uses crypto/rand")
+	id := NewGenerator(nil).NewV4()
+	ExpectedActual(t, 4, id.Version(), "nil reader v4")
+}
@@ -0,0 +1,41 @@
+package uuid
+
+import (
+	"crypto/sha1"
+
+	"github.com/seanpfeifer/rigging/hashing"
+)
+
+// Namespaces for NewV5 from RFC 9562. Any ID can be used as a namespace, these are just the well-known ones.
+var (
+	NamespaceDNS  = MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceURL  = MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceOID  = MustParse("6ba7b812-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceX500 = MustParse("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+)
+
+// NewV5 returns the RFC 9562 version 5 ID for name in the given namespace, which is the SHA-1 hash of both.
+// The same namespace and name always give the same ID, so it can be derived again later instead of stored.
+//
+// Anyone who can guess the name can work out the ID - use NewKeyed if that matters, eg for IDs derived from emails.
+func NewV5(namespace RandomID, name string) RandomID {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write([]byte(name))
+
+	var id RandomID
+	copy(id[:], h.Sum(nil))
+	id.setVersion(5)
+	return id
+}
+
+// NewKeyed returns an ID derived from name using an HMAC with key. Like NewV5, the same key and name always give the
+// same ID, but without the key nobody can work out the ID for a name, or check a guess of the name for an ID.
+//
+// The ID has the RFC 9562 version 8 (custom) and variant bits set, so it's accepted anywhere a UUID is.
+func NewKeyed(key *hashing.HMACKey, name string) RandomID {
+	var id RandomID
+	copy(id[:], key.Hash(name))
+	id.setVersion(8)
+	return id
+}
@@ -0,0 +1,33 @@
+package uuid
+
+import (
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/entropy"
+	"github.com/seanpfeifer/rigging/hashing"
+)
+
+func TestV5(t *testing.T) {
+	// Test vector from RFC 9562, Appendix A.4.
+	id := NewV5(NamespaceDNS, "www.example.com")
+	ExpectedActual(t, "2ed6657d-e927-568b-95e1-2665a8aea6a2", id.Canonical(), "rfc vector")
+	ExpectedActual(t, 5, id.Version(), "version")
+	ExpectedActual(t, true, id.IsRFC(), "variant")
+
+	ExpectedActual(t, id, NewV5(NamespaceDNS, "www.example.com"), "stable")
+	ExpectedActual(t, false, id == NewV5(NamespaceURL, "www.example.com"), "namespace matters")
+	ExpectedActual(t, false, id == NewV5(NamespaceDNS, "www.example.org"), "name matters")
+}
+
+func TestKeyed(t *testing.T) {
+	key := hashing.NewHMACKeyFrom(entropy.Seeded(1))
+	id := NewKeyed(&key, "someone@example.com")
+	ExpectedActual(t, 8, id.Version(), "version")
+	ExpectedActual(t, true, id.IsRFC(), "variant")
+	ExpectedActual(t, id, NewKeyed(&key, "someone@example.com"), "stable")
+	ExpectedActual(t, false, id == NewKeyed(&key, "someone.else@example.com"), "name matters")
+
+	otherKey := hashing.NewHMACKeyFrom(entropy.Seeded(2))
+	ExpectedActual(t, false, id == NewKeyed(&otherKey, "someone@example.com"), "key matters")
+}
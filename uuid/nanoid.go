@@ -0,0 +1,113 @@
+package uuid
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// Alphabets for NewString.
+const (
+	// AlphabetURLSafe is the 64 characters that don't need escaping in a URL, as used by NanoID.
+	AlphabetURLSafe = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz_-"
+	// AlphabetAlphanumeric is the digits and letters, for places that don't allow punctuation.
+	AlphabetAlphanumeric = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// AlphabetNoLookalikes skips the characters that are easily confused when read by a person: 0/O/o, 1/I/l, and
+	// the vowels, which also avoids spelling most words. Good for invite codes.
+	AlphabetNoLookalikes = "23456789BCDFGHJKMNPQRSTVWXYZbcdfghjkmnpqrstvwxyz"
+)
+
+// NewString returns a random string of length characters from alphabet, such as an invite code, short link, or API key.
+// Every character is equally likely - see Generator.NewString.
+func NewString(alphabet string, length int) string {
+	var g Generator
+	return g.NewString(alphabet, length)
+}
+
+// NewString is like the package-level NewString, using g's random bytes.
+//
+// Each character is picked by masking a random byte down to the next power of 2 above the alphabet size and trying
+// again if it's too large, rather than using a modulo, which would make the first few characters more likely.
+// It panics if the alphabet has fewer than 2 characters, has any repeats, or isn't ASCII.
+func (g *Generator) NewString(alphabet string, length int) string {
+	checkAlphabet(alphabet)
+	if length <= 0 {
+		return ""
+	}
+	mask := byte(1<<bits.Len8(uint8(len(alphabet)-1)) - 1)
+	// Read enough to usually finish in one go, given how many bytes we expect to throw away.
+	step := int(math.Ceil(1.6 * float64(int(mask)+1) * float64(length) / float64(len(alphabet))))
+
+	out := make([]byte, 0, length)
+	random := make([]byte, step)
+	for {
+		g.read(random)
+		for _, b := range random {
+			if i := b & mask; int(i) < len(alphabet) {
+				out = append(out, alphabet[i])
+				if len(out) == length {
+					return string(out)
+				}
+			}
+		}
+	}
+}
+
+// ExpectedCollisionTime returns how long it takes, on average, before two random strings of length characters from
+// alphabet are the same, if perSecond strings are made every second. This uses the birthday paradox, so it's much
+// sooner than you might think - use it to pick a length that's safe for your rate.
+//
+// Durations longer than about 292 years are capped at the largest time.Duration. It panics if perSecond isn't
+// positive, length isn't positive, or the alphabet isn't one NewString accepts.
+func ExpectedCollisionTime(alphabet string, length int, perSecond float64) time.Duration {
+	checkAlphabet(alphabet)
+	checkCollisionLength(length)
+	if !(perSecond > 0) { // Also catches NaN
+		panic("uuid: ExpectedCollisionTime perSecond must be positive")
+	}
+	// The expected number of strings before the first repeat is sqrt(pi/2 * N), where N = len(alphabet)^length.
+	// That's done with logs, since N quickly gets too large for a float64.
+	logStrings := 0.5 * (math.Log(math.Pi/2) + float64(length)*math.Log(float64(len(alphabet))))
+	seconds := math.Exp(logStrings - math.Log(perSecond))
+	if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+		return math.MaxInt64
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// CollisionProbability returns the chance that at least two of count random strings of length characters from alphabet
+// are the same. It panics if count is negative, length isn't positive, or the alphabet isn't one NewString accepts.
+func CollisionProbability(alphabet string, length int, count float64) float64 {
+	checkAlphabet(alphabet)
+	checkCollisionLength(length)
+	if !(count >= 0) { // Also catches NaN
+		panic("uuid: CollisionProbability count must not be negative")
+	}
+	// 1 - e^(-count^2 / 2N), using Expm1 so tiny probabilities don't round to 0.
+	logN := float64(length) * math.Log(float64(len(alphabet)))
+	return -math.Expm1(-math.Exp(2*math.Log(count) - math.Ln2 - logN))
+}
+
+func checkCollisionLength(length int) {
+	if length <= 0 {
+		panic("uuid: length must be positive, got " + strconv.Itoa(length))
+	}
+}
+
+func checkAlphabet(alphabet string) {
+	if len(alphabet) < 2 {
+		panic("uuid: alphabet must have at least 2 characters, got " + strconv.Itoa(len(alphabet)))
+	}
+	var seen [128]bool
+	for i := range len(alphabet) {
+		c := alphabet[i]
+		if c >= 0x80 {
+			panic("uuid: alphabet must be ASCII")
+		}
+		if seen[c] {
+			panic("uuid: alphabet has " + strconv.QuoteRune(rune(c)) + " more than once")
+		}
+		seen[c] = true
+	}
+}
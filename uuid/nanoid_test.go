@@ -0,0 +1,94 @@
+package uuid
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/entropy"
+)
+
+func TestNewString(t *testing.T) {
+	for _, alphabet := range []string{AlphabetURLSafe, AlphabetAlphanumeric, AlphabetNoLookalikes, "01"} {
+		s := NewString(alphabet, 21)
+		ExpectedActual(t, 21, len(s), alphabet+" length")
+		for _, c := range s {
+			if !ExpectedActual(t, true, strings.ContainsRune(alphabet, c), alphabet+" character") {
+				break
+			}
+		}
+	}
+	ExpectedActual(t, "", NewString(AlphabetURLSafe, 0), "empty")
+	ExpectedActual(t, false, NewString(AlphabetURLSafe, 21) == NewString(AlphabetURLSafe, 21), "random")
+
+	a := NewGenerator(entropy.Seeded(1)).NewString(AlphabetNoLookalikes, 8)
+	b := NewGenerator(entropy.Seeded(1)).NewString(AlphabetNoLookalikes, 8)
+	ExpectedActual(t, a, b, "deterministic")
+
+	for _, bad := range []string{"", "a", "abca", "abcé"} {
+		func() {
+			defer func() {
+				ExpectedActual(t, true, recover() != nil, "bad alphabet panics "+bad)
+			}()
+			NewString(bad, 4)
+		}()
+	}
+}
+
+func TestNewStringUnbiased(t *testing.T) {
+	// With 3 characters, a modulo of a random byte would pick "a" 86 times out of 256 and "c" only 85, and with 48
+	// characters (like AlphabetNoLookalikes) the first 16 would be 20% more likely. Check every character is within a
+	// few percent of even.
+	for _, alphabet := range []string{"abc", AlphabetNoLookalikes} {
+		const perChar = 20_000
+		counts := map[rune]int{}
+		for _, c := range NewGenerator(entropy.Seeded(3)).NewString(alphabet, perChar*len(alphabet)) {
+			counts[c]++
+		}
+		for _, c := range alphabet {
+			ExpectedApprox(t, perChar, counts[c], perChar/20, alphabet+" "+string(c))
+		}
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	// 10^6 possible strings repeat after about 1253 on average.
+	ExpectedApprox(t, 1253*time.Second, ExpectedCollisionTime("0123456789", 6, 1), time.Second, "digits")
+	ExpectedApprox(t, 1253*time.Millisecond, ExpectedCollisionTime("0123456789", 6, 1000), time.Millisecond, "rate")
+	ExpectedActual(t, time.Duration(math.MaxInt64), ExpectedCollisionTime(AlphabetURLSafe, 21, 1000), "capped")
+	// 8 lookalike-free characters at 100 invite codes per second.
+	ExpectedApprox(t, 18*time.Hour+29*time.Minute, ExpectedCollisionTime(AlphabetNoLookalikes, 8, 100), time.Minute, "invite codes")
+
+	ExpectedApprox(t, 0.544, CollisionProbability("0123456789", 6, 1253), 0.001, "probability at expected count")
+	ExpectedApprox(t, 0.0, CollisionProbability("0123456789", 6, 1), 1e-6, "single")
+	ExpectedApprox(t, 1.0, CollisionProbability("0123456789", 6, 1e5), 1e-9, "certain")
+	ExpectedApprox(t, 1.469e-7, CollisionProbability(AlphabetURLSafe, 21, 5e15), 1e-10, "nanoid default")
+	ExpectedActual(t, 0.0, CollisionProbability("0123456789", 6, 0), "none")
+
+	for name, tc := range map[string]struct {
+		f        func()
+		expected string
+	}{
+		"zero rate":      {func() { ExpectedCollisionTime("0123456789", 6, 0) }, "uuid: ExpectedCollisionTime perSecond must be positive"},
+		"negative rate":  {func() { ExpectedCollisionTime("0123456789", 6, -1) }, "uuid: ExpectedCollisionTime perSecond must be positive"},
+		"nan rate":       {func() { ExpectedCollisionTime("0123456789", 6, math.NaN()) }, "uuid: ExpectedCollisionTime perSecond must be positive"},
+		"negative count": {func() { CollisionProbability("0123456789", 6, -1) }, "uuid: CollisionProbability count must not be negative"},
+		"nan count":      {func() { CollisionProbability("0123456789", 6, math.NaN()) }, "uuid: CollisionProbability count must not be negative"},
+		"zero length":    {func() { CollisionProbability("0123456789", 0, 10) }, "uuid: length must be positive, got 0"},
+	} {
+		func() {
+			defer func() {
+				ExpectedActual[any](t, tc.expected, recover(), name)
+			}()
+			tc.f()
+		}()
+	}
+}
+
+func BenchmarkNewString(b *testing.B) {
+	for b.Loop() {
+		NewString(AlphabetURLSafe, 21)
+	}
+}
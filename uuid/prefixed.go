@@ -0,0 +1,109 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrWrongPrefix is returned (wrapped, along with ErrInvalidID) when parsing an ID with a different type's prefix.
+var ErrWrongPrefix = errors.New("wrong ID prefix")
+
+// Prefixer gives the prefix for a type of ID, eg "user". It's normally implemented by an empty struct that's only
+// used as a type parameter:
+//
+//	type User struct{}
+//
+//	func (User) Prefix() string { return "user" }
+//
+//	type UserID = uuid.ID[User]
+type Prefixer interface {
+	Prefix() string
+}
+
+// ID is a RandomID that belongs to one type of thing, and is written with that type's prefix, eg
+// "user_7r3n7twzfc278aes80m34hwtzp". Different types of ID can't be mixed up at compile time, and parsing refuses an ID
+// with the wrong prefix, so an order ID can't be passed where a user ID is expected.
+//
+// Convert to and from a RandomID with ID[T](r) and RandomID(id).
+type ID[T Prefixer] RandomID
+
+// NewID returns a new random ID of type T.
+func NewID[T Prefixer]() ID[T] {
+	return ID[T](NewRandom())
+}
+
+// ParseID parses an ID in the form returned by String. The base32 part is parsed with ParseBase32, so it's forgiving
+// of case and look-alike characters, but the prefix must match exactly.
+func ParseID[T Prefixer](s string) (ID[T], error) {
+	prefix := idPrefix[T]()
+	rest, ok := strings.CutPrefix(s, prefix+"_")
+	if !ok {
+		return ID[T]{}, fmt.Errorf("%w: %w: expected %q in %q", ErrInvalidID, ErrWrongPrefix, prefix+"_", s)
+	}
+	id, err := ParseBase32(rest)
+	if err != nil {
+		return ID[T]{}, err
+	}
+	return ID[T](id), nil
+}
+
+// Prefix returns the prefix for this type of ID, without the underscore.
+func (id ID[T]) Prefix() string {
+	return idPrefix[T]()
+}
+
+// String returns the prefix, an underscore, and the ID in lowercase Crockford base32.
+func (id ID[T]) String() string {
+	return idPrefix[T]() + "_" + strings.ToLower(RandomID(id).Base32())
+}
+
+// IsZero returns true if id is the zero ID.
+func (id ID[T]) IsZero() bool {
+	return RandomID(id).IsZero()
+}
+
+// MarshalText implements encoding.TextMarshaler, using String. This is used by JSON and TOML.
+func (id ID[T]) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, using ParseID.
+func (id *ID[T]) UnmarshalText(text []byte) error {
+	parsed, err := ParseID[T](string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The prefix is only for people and type checks, so this stores the same Canonical
+// form as RandomID, which fits a uuid column.
+func (id ID[T]) Value() (driver.Value, error) {
+	return RandomID(id).Value()
+}
+
+// Scan implements sql.Scanner. It accepts anything RandomID.Scan does, as well as the prefixed form from String.
+func (id *ID[T]) Scan(src any) error {
+	var text string
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		if len(v) != len(RandomID{}) { // 16 raw bytes could contain an underscore
+			text = string(v)
+		}
+	}
+	if strings.Contains(text, "_") {
+		return id.UnmarshalText([]byte(text))
+	}
+	return (*RandomID)(id).Scan(src)
+}
+
+// idPrefix returns T's prefix. T is usually an empty struct, so the zero value is all we need.
+func idPrefix[T Prefixer]() string {
+	var t T
+	return t.Prefix()
+}
@@ -0,0 +1,115 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/fileload"
+)
+
+type testUser struct{}
+
+func (testUser) Prefix() string { return "user" }
+
+type testOrder struct{}
+
+func (testOrder) Prefix() string { return "order" }
+
+type (
+	userID  = ID[testUser]
+	orderID = ID[testOrder]
+)
+
+var (
+	_ encoding.TextMarshaler   = userID{}
+	_ encoding.TextUnmarshaler = (*userID)(nil)
+	_ driver.Valuer            = userID{}
+	_ sql.Scanner              = (*userID)(nil)
+)
+
+func TestPrefixedID(t *testing.T) {
+	id := userID(MustParse(testCanonical))
+	ExpectedActual(t, "user", id.Prefix(), "prefix")
+	ExpectedActual(t, "user_7r3n7twzfc278aes80m34hwtzp", id.String(), "string")
+	ExpectedActual(t, MustParse(testCanonical), RandomID(id), "convert back")
+
+	for _, s := range []string{id.String(), "user_7R3N7TWZFC278AES80M34HWTZP", "user_7r3n-7twz-fc27-8aes-8om3-4hwt-zp"} {
+		back, err := ParseID[testUser](s)
+		ExpectedActual(t, nil, err, s+" error")
+		ExpectedActual(t, id, back, s)
+	}
+
+	_, err := ParseID[testOrder](id.String())
+	ExpectedActual(t, true, errors.Is(err, ErrWrongPrefix), "wrong prefix")
+	ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "wrong prefix is invalid")
+	ExpectedActual(t, `invalid ID: wrong ID prefix: expected "order_" in "user_7r3n7twzfc278aes80m34hwtzp"`, err.Error(), "wrong prefix message")
+	for _, bad := range []string{"", "user", "user_", "users_7r3n7twzfc278aes80m34hwtzp", "USER_7r3n7twzfc278aes80m34hwtzp", "user_7r3n7twzfc278aes80m34hwtz"} {
+		_, err := ParseID[testUser](bad)
+		ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "invalid "+bad)
+	}
+
+	ExpectedActual(t, true, userID{}.IsZero(), "zero")
+	random := NewID[testUser]()
+	ExpectedActual(t, false, random.IsZero(), "random isn't zero")
+	back, err := ParseID[testUser](random.String())
+	ExpectedActual(t, nil, err, "random error")
+	ExpectedActual(t, random, back, "random round trip")
+}
+
+type orderRecord struct {
+	ID    orderID
+	Buyer userID
+}
+
+func TestPrefixedIDEncoding(t *testing.T) {
+	rec := orderRecord{ID: NewID[testOrder](), Buyer: userID(MustParse(testCanonical))}
+	data, err := json.Marshal(rec)
+	ExpectedActual(t, nil, err, "marshal error")
+	ExpectedActual(t, `{"ID":"`+rec.ID.String()+`","Buyer":"user_7r3n7twzfc278aes80m34hwtzp"}`, string(data), "marshal")
+	var back orderRecord
+	ExpectedActual(t, nil, json.Unmarshal(data, &back), "unmarshal error")
+	ExpectedActual(t, rec, back, "round trip")
+
+	swapped := `{"ID":"user_7r3n7twzfc278aes80m34hwtzp","Buyer":"user_7r3n7twzfc278aes80m34hwtzp"}`
+	ExpectedActual(t, true, errors.Is(json.Unmarshal([]byte(swapped), &back), ErrWrongPrefix), "swapped json")
+
+	file := filepath.Join(t.TempDir(), "order.toml")
+	ExpectedActual(t, nil, os.WriteFile(file, []byte("ID = \""+rec.ID.String()+"\"\nBuyer = \"user_7r3n7twzfc278aes80m34hwtzp\"\n"), 0o600), "writing toml")
+	cfg, _, err := fileload.TOML[orderRecord](file)
+	ExpectedActual(t, nil, err, "loading toml")
+	ExpectedActual(t, rec, *cfg, "toml")
+}
+
+func TestPrefixedIDSQL(t *testing.T) {
+	id := userID(MustParse(testCanonical))
+	v, err := id.Value()
+	ExpectedActual(t, nil, err, "value error")
+	ExpectedActual(t, driver.Value(testCanonical), v, "value is canonical")
+
+	raw := MustParse(testCanonical)
+	for name, src := range map[string]any{
+		"canonical": v,
+		"raw bytes": raw[:],
+		"prefixed":  id.String(),
+		"bytes":     []byte(id.String()),
+	} {
+		var back userID
+		ExpectedActual(t, nil, back.Scan(src), name+" error")
+		ExpectedActual(t, id, back, name)
+	}
+
+	underscore := RandomID{0: '_'}
+	var back userID
+	ExpectedActual(t, nil, back.Scan(underscore[:]), "raw bytes with underscore error")
+	ExpectedActual(t, userID(underscore), back, "raw bytes with underscore")
+	ExpectedActual(t, true, errors.Is(back.Scan("order_7r3n7twzfc278aes80m34hwtzp"), ErrWrongPrefix), "wrong prefix")
+	ExpectedActual(t, nil, back.Scan(nil), "null")
+	ExpectedActual(t, true, back.IsZero(), "null is zero")
+}
@@ -5,10 +5,11 @@ import (
 	"encoding/hex"
 )
 
-// RandomID is a random 16 byte ID. This is similar to a random UUID (RFC 4122), except it lacks the formatting and is
-// purely 128 random bits. This is for simple use cases where all we care about is that we have a unique, random identifier.
+// RandomID is a random 16 byte ID. This is similar to a random UUID (RFC 9562), except it's purely 128 random bits
+// without the version and variant bits set. This is for simple use cases where all we care about is that we have a
+// unique, random identifier.
 //
-// If you want something that abides by RFC 4122, use https://github.com/google/uuid
+// If you need an ID that other systems will accept as a UUID, use NewV4 instead, and Canonical to format it.
 type RandomID [16]byte
 
 // NewRandom returns a new random ID.
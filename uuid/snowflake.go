@@ -0,0 +1,153 @@
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/seanpfeifer/rigging/num"
+)
+
+// ErrClockRollback is returned by Snowflake.Next when the clock has gone backwards by more than MaxRollbackWait.
+var ErrClockRollback = errors.New("clock moved backwards")
+
+// ErrBeforeEpoch is returned by Snowflake.Next when the clock reads a time before the configured epoch.
+var ErrBeforeEpoch = errors.New("time is before the epoch")
+
+// DefaultSnowflakeEpoch is the epoch used when SnowflakeConfig.Epoch isn't set.
+var DefaultSnowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SnowflakeConfig configures a Snowflake generator. The zero value uses DefaultSnowflakeEpoch, 10 node bits, and 12
+// sequence bits, which is the same layout as Twitter's: 41 bits of milliseconds (about 69 years), 1024 nodes, and 4096
+// IDs per node per millisecond.
+type SnowflakeConfig struct {
+	Epoch time.Time
+	// NodeBits and SequenceBits set the layout. Zero means the default, so neither can actually be 0 - for a single
+	// node, use 1 node bit and node 0, which only costs half the time range.
+	NodeBits     uint
+	SequenceBits uint
+	// MaxRollbackWait is how far back the clock can go (eg from an NTP adjustment) before Next gives up and returns
+	// ErrClockRollback. For smaller rollbacks, Next waits for the clock to catch up. Zero means never wait.
+	MaxRollbackWait time.Duration
+	// Now and Sleep replace time.Now and time.Sleep when set, for tests.
+	Now   func() time.Time
+	Sleep func(time.Duration)
+}
+
+// Snowflake makes 64-bit IDs that sort by creation time, for storage that wants integer keys. Each ID is a positive
+// int64 made of milliseconds since the epoch, then the node ID, then a sequence number for IDs in the same millisecond.
+// Every node making IDs at the same time must have a different node ID. It's safe for concurrent use.
+type Snowflake struct {
+	mu       sync.Mutex
+	cfg      SnowflakeConfig
+	node     int64
+	timeBits uint
+	lastMs   int64
+	seq      int64
+}
+
+// SnowflakeParts is an ID split back into the parts it was made from.
+type SnowflakeParts struct {
+	Time     time.Time
+	Node     int64
+	Sequence int64
+}
+
+// NewSnowflake returns a generator for the given node ID, which must fit in cfg.NodeBits.
+func NewSnowflake(node int64, cfg SnowflakeConfig) (*Snowflake, error) {
+	if cfg.Epoch.IsZero() {
+		cfg.Epoch = DefaultSnowflakeEpoch
+	}
+	if cfg.NodeBits == 0 {
+		cfg.NodeBits = 10
+	}
+	if cfg.SequenceBits == 0 {
+		cfg.SequenceBits = 12
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Sleep == nil {
+		cfg.Sleep = time.Sleep
+	}
+
+	// The sign bit is never used, so IDs are always positive.
+	if cfg.NodeBits+cfg.SequenceBits >= 63 {
+		return nil, fmt.Errorf("uuid: snowflake needs room for a timestamp, but has %d node bits and %d sequence bits", cfg.NodeBits, cfg.SequenceBits)
+	}
+	if node < 0 || node >= 1<<cfg.NodeBits {
+		return nil, fmt.Errorf("uuid: snowflake node %d doesn't fit in %d bits", node, cfg.NodeBits)
+	}
+	return &Snowflake{
+		cfg:      cfg,
+		node:     node,
+		timeBits: 63 - cfg.NodeBits - cfg.SequenceBits,
+		lastMs:   -1,
+	}, nil
+}
+
+// Next returns the next ID. If all the sequence numbers for this millisecond have been used, it waits for the next one.
+// It returns an error wrapping ErrClockRollback if the clock has gone backwards too far to wait for, ErrBeforeEpoch if
+// it reads a time before the epoch, or num.ErrOverflow if the time is too far past the epoch to fit.
+func (s *Snowflake) Next() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now, ms := s.now()
+	if ms < 0 {
+		return 0, fmt.Errorf("%w: snowflake time %v is before %v", ErrBeforeEpoch, now, s.cfg.Epoch)
+	}
+	if ms < s.lastMs {
+		behind := time.Duration(s.lastMs-ms) * time.Millisecond
+		if behind > s.cfg.MaxRollbackWait {
+			return 0, fmt.Errorf("%w: by %v", ErrClockRollback, behind)
+		}
+		s.cfg.Sleep(behind)
+		for now, ms = s.now(); ms < s.lastMs; now, ms = s.now() {
+			s.cfg.Sleep(time.Millisecond)
+		}
+	}
+
+	if ms == s.lastMs {
+		s.seq = (s.seq + 1) & (1<<s.cfg.SequenceBits - 1)
+		if s.seq == 0 {
+			// Out of sequence numbers, so wait for the next millisecond.
+			for ms <= s.lastMs {
+				s.cfg.Sleep(time.Millisecond)
+				now, ms = s.now()
+			}
+		}
+	} else {
+		s.seq = 0
+	}
+
+	if ms >= 1<<s.timeBits {
+		return 0, fmt.Errorf("%w: snowflake time %v doesn't fit in %d bits after the epoch %v", num.ErrOverflow,
+			now, s.timeBits, s.cfg.Epoch)
+	}
+	s.lastMs = ms
+	return ms<<(s.cfg.NodeBits+s.cfg.SequenceBits) | s.node<<s.cfg.SequenceBits | s.seq, nil
+}
+
+// Decompose splits id into the time, node, and sequence number it was made from. It must have been made with the same
+// config.
+func (s *Snowflake) Decompose(id int64) SnowflakeParts {
+	ms := id >> (s.cfg.NodeBits + s.cfg.SequenceBits)
+	// A time.Duration only covers about 292 years, which a layout with lots of time bits can pass, so add whole seconds
+	// separately from the leftover milliseconds.
+	epoch := s.cfg.Epoch
+	t := time.Unix(epoch.Unix()+ms/1000, int64(epoch.Nanosecond())+ms%1000*int64(time.Millisecond))
+	return SnowflakeParts{
+		Time:     t.In(epoch.Location()),
+		Node:     id >> s.cfg.SequenceBits & (1<<s.cfg.NodeBits - 1),
+		Sequence: id & (1<<s.cfg.SequenceBits - 1),
+	}
+}
+
+// now returns the current time, and the milliseconds since the epoch. This uses UnixMilli rather than Sub, since a
+// time.Duration stops at about 292 years.
+func (s *Snowflake) now() (time.Time, int64) {
+	now := s.cfg.Now()
+	return now, now.UnixMilli() - s.cfg.Epoch.UnixMilli()
+}
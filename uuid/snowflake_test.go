@@ -0,0 +1,148 @@
+package uuid
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/num"
+)
+
+func newTestSnowflake(t *testing.T, node int64, cfg SnowflakeConfig) (*Snowflake, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{now: DefaultSnowflakeEpoch.Add(time.Hour)}
+	cfg.Now = clock.Now
+	cfg.Sleep = clock.Add
+	s, err := NewSnowflake(node, cfg)
+	ExpectedActual(t, nil, err, "new snowflake")
+	return s, clock
+}
+
+func TestSnowflake(t *testing.T) {
+	s, clock := newTestSnowflake(t, 5, SnowflakeConfig{})
+	id, err := s.Next()
+	ExpectedActual(t, nil, err, "next error")
+	ExpectedActual(t, int64(3_600_000)<<22|5<<12, id, "layout")
+	ExpectedActual(t, SnowflakeParts{Time: clock.Now(), Node: 5, Sequence: 0}, s.Decompose(id), "decompose")
+
+	id2, _ := s.Next()
+	ExpectedActual(t, SnowflakeParts{Time: clock.Now(), Node: 5, Sequence: 1}, s.Decompose(id2), "same millisecond")
+	clock.Add(time.Millisecond)
+	id3, _ := s.Next()
+	ExpectedActual(t, SnowflakeParts{Time: clock.Now(), Node: 5, Sequence: 0}, s.Decompose(id3), "next millisecond")
+	ExpectedActual(t, true, id < id2 && id2 < id3, "increasing")
+}
+
+func TestSnowflakeSequenceExhausted(t *testing.T) {
+	s, clock := newTestSnowflake(t, 1, SnowflakeConfig{NodeBits: 4, SequenceBits: 2})
+	start := clock.Now()
+	var last int64
+	for i := range 9 {
+		id, err := s.Next()
+		ExpectedActual(t, nil, err, "next error")
+		ExpectedActual(t, true, id > last, "increasing")
+		last = id
+		parts := s.Decompose(id)
+		// 4 IDs per millisecond, then it has to wait for the clock.
+		ExpectedActual(t, start.Add(time.Duration(i/4)*time.Millisecond), parts.Time, "time")
+		ExpectedActual(t, int64(i%4), parts.Sequence, "sequence")
+		ExpectedActual(t, int64(1), parts.Node, "node")
+	}
+}
+
+func TestSnowflakeRollback(t *testing.T) {
+	s, clock := newTestSnowflake(t, 0, SnowflakeConfig{MaxRollbackWait: 10 * time.Millisecond})
+	first, _ := s.Next()
+
+	clock.Add(-5 * time.Millisecond)
+	id, err := s.Next()
+	ExpectedActual(t, nil, err, "small rollback waits")
+	ExpectedActual(t, true, id > first, "still increasing")
+	ExpectedActual(t, s.Decompose(first).Time, clock.Now(), "waited for the clock to catch up")
+
+	clock.Add(-time.Second)
+	_, err = s.Next()
+	ExpectedActual(t, true, errors.Is(err, ErrClockRollback), "large rollback errors")
+	ExpectedActual(t, "clock moved backwards: by 1s", err.Error(), "rollback message")
+
+	strict, clock := newTestSnowflake(t, 0, SnowflakeConfig{})
+	_, _ = strict.Next()
+	clock.Add(-time.Millisecond)
+	_, err = strict.Next()
+	ExpectedActual(t, true, errors.Is(err, ErrClockRollback), "no waiting by default")
+}
+
+func TestSnowflakeConfig(t *testing.T) {
+	_, err := NewSnowflake(1024, SnowflakeConfig{})
+	ExpectedActual(t, true, err != nil, "node too large")
+	_, err = NewSnowflake(-1, SnowflakeConfig{})
+	ExpectedActual(t, true, err != nil, "negative node")
+	_, err = NewSnowflake(0, SnowflakeConfig{NodeBits: 40, SequenceBits: 23})
+	ExpectedActual(t, true, err != nil, "no room for time")
+
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s, clock := newTestSnowflake(t, 3, SnowflakeConfig{Epoch: epoch, NodeBits: 20, SequenceBits: 20})
+	_, err = s.Next()
+	ExpectedActual(t, true, errors.Is(err, ErrBeforeEpoch), "before epoch")
+	ExpectedActual(t, false, errors.Is(err, num.ErrOverflow), "before epoch isn't overflow")
+	clock.Add(epoch.Sub(clock.Now()) + time.Minute)
+	id, err := s.Next()
+	ExpectedActual(t, nil, err, "after epoch")
+	ExpectedActual(t, SnowflakeParts{Time: clock.Now(), Node: 3}, s.Decompose(id), "custom layout")
+	clock.Add(time.Duration(1<<23) * time.Millisecond) // 23 bits of time is a little over 2 hours
+	_, err = s.Next()
+	ExpectedActual(t, true, errors.Is(err, num.ErrOverflow), "past the end")
+}
+
+func TestSnowflakeDecomposeLongTime(t *testing.T) {
+	s, _ := newTestSnowflake(t, 0, SnowflakeConfig{NodeBits: 1, SequenceBits: 1})
+	const ms = 1<<60 + 1234 // Millions of years, far past what a time.Duration holds
+	parts := s.Decompose(ms<<2 | 1)
+	ExpectedActual(t, time.UnixMilli(DefaultSnowflakeEpoch.UnixMilli()+ms).UTC(), parts.Time, "time")
+	ExpectedActual(t, int64(1), parts.Sequence, "sequence")
+}
+
+func TestSnowflakeFarFromEpoch(t *testing.T) {
+	s, clock := newTestSnowflake(t, 0, SnowflakeConfig{NodeBits: 1, SequenceBits: 1})
+	clock.now = DefaultSnowflakeEpoch.AddDate(400, 0, 0) // Past where a time.Duration saturates
+	first, err := s.Next()
+	ExpectedActual(t, nil, err, "first")
+	ExpectedActual(t, clock.Now(), s.Decompose(first).Time, "first time")
+
+	clock.Add(time.Millisecond)
+	second, err := s.Next()
+	ExpectedActual(t, nil, err, "second")
+	ExpectedActual(t, clock.Now(), s.Decompose(second).Time, "second time")
+	ExpectedActual(t, true, second > first, "increasing")
+}
+
+func TestSnowflakeConcurrent(t *testing.T) {
+	s, err := NewSnowflake(7, SnowflakeConfig{})
+	ExpectedActual(t, nil, err, "new")
+	const workers, perWorker = 8, 2000
+	results := make([][]int64, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for range perWorker {
+				id, err := s.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[w] = append(results[w], id)
+			}
+		})
+	}
+	wg.Wait()
+
+	seen := map[int64]bool{}
+	for _, ids := range results {
+		for _, id := range ids {
+			seen[id] = true
+		}
+	}
+	ExpectedActual(t, workers*perWorker, len(seen), "unique")
+}
@@ -0,0 +1,107 @@
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrBadCheckCharacter is returned (wrapped, along with ErrInvalidID) when a support code's check character doesn't
+// match, which almost always means it was mistyped.
+var ErrBadCheckCharacter = errors.New("support code check character doesn't match")
+
+// supportCodeGroup is the number of characters between dashes in a support code.
+const supportCodeGroup = 4
+
+// SupportCode returns a short code for the ID that's easy to read over the phone, eg "Z0EM-ZBKX-X1". It's the first
+// 5*length bits of the ID in Crockford's base32, plus a check character, grouped into fours. length can be from 1 to
+// 26, and 26 contains the whole ID.
+//
+// Shorter codes can match more than one ID. Don't use them for time-ordered IDs like NewV7, as IDs made around the same
+// time start with the same bits. With 8 characters (40 bits), there's a 1% chance two IDs share a code once
+// there are around 150,000 IDs, so only use them to look up IDs you already expect, like a customer's recent orders.
+//
+// The check character catches every single mistyped character and every swap of two adjacent characters.
+func (r RandomID) SupportCode(length int) string {
+	if length < 1 || length > crockfordLen {
+		panic("uuid: support code length must be 1 to 26, got " + strconv.Itoa(length))
+	}
+	values := make([]byte, length, length+1)
+	hi, lo := r.halves()
+	for i := range values {
+		values[i] = byte(hi >> 59)
+		hi = hi<<5 | lo>>59
+		lo <<= 5
+	}
+	values = append(values, dammCheck(values))
+	return formatSupportCode(values)
+}
+
+// ParseSupportCode checks the code's check character, and returns it in the same form as SupportCode. It's forgiving of
+// the ways people type codes: case is ignored, dashes and spaces are skipped, and I and L are read as 1 and O as 0.
+func ParseSupportCode(code string) (string, error) {
+	values := make([]byte, 0, len(code))
+	for i := range len(code) {
+		c := code[i]
+		if c == '-' || c == ' ' {
+			continue
+		}
+		v := crockfordLenientValues[c]
+		if v == 0xff {
+			return "", fmt.Errorf("%w: invalid support code character %q at position %d", ErrInvalidID, c, i)
+		}
+		values = append(values, v)
+	}
+	if len(values) < 2 || len(values) > crockfordLen+1 {
+		return "", fmt.Errorf("%w: support code must be 2 to %d characters without dashes, got %d", ErrInvalidID, crockfordLen+1, len(values))
+	}
+	if dammCheck(values) != 0 {
+		return "", fmt.Errorf("%w: %w: %q", ErrInvalidID, ErrBadCheckCharacter, code)
+	}
+	return formatSupportCode(values), nil
+}
+
+// MatchesSupportCode returns true if code is a valid support code for this ID, in any form ParseSupportCode accepts.
+func (r RandomID) MatchesSupportCode(code string) bool {
+	parsed, err := ParseSupportCode(code)
+	if err != nil {
+		return false
+	}
+	length := len(strings.ReplaceAll(parsed, "-", "")) - 1
+	return r.SupportCode(length) == parsed
+}
+
+func formatSupportCode(values []byte) string {
+	var sb strings.Builder
+	for i, v := range values {
+		if i > 0 && i%supportCodeGroup == 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(crockfordAlphabet[v])
+	}
+	return sb.String()
+}
+
+// dammCheck returns the check character for values, using the Damm algorithm over the 32 base32 values. Checking
+// values with their check character on the end returns 0.
+//
+// Damm needs an operation where (c∘x)∘y = (c∘y)∘x only when x = y, which is what catches adjacent swaps.
+// a∘b = 2a XOR b in GF(32) does this, as it works out to (x XOR y) * (2 XOR 1) = 0, which means x = y.
+func dammCheck(values []byte) byte {
+	var interim byte
+	for _, v := range values {
+		interim = gf32Double(interim) ^ v
+	}
+	// The check character c needs interim∘c = 0, which is c = 2 * interim.
+	return gf32Double(interim)
+}
+
+// gf32Double multiplies x by 2 in GF(32), using the primitive polynomial x^5 + x^2 + 1.
+func gf32Double(x byte) byte {
+	x <<= 1
+	if x&0x20 != 0 {
+		x ^= 0x25
+	}
+	return x
+}
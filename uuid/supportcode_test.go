@@ -0,0 +1,75 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/seanpfeifer/rigging/assert"
+	"github.com/seanpfeifer/rigging/entropy"
+)
+
+func TestSupportCode(t *testing.T) {
+	id := MustParse(testCanonical)
+	code := id.SupportCode(9)
+	ExpectedActual(t, "Z0EM-ZBKX-X1", code, "code")
+	ExpectedActual(t, "Z0EM-ZBKX-X", id.SupportCode(26)[:11], "full length starts the same")
+	ExpectedActual(t, true, id.MatchesSupportCode(id.SupportCode(26)), "full length")
+	ExpectedActual(t, 2, len(id.SupportCode(1)), "shortest")
+
+	for _, typed := range []string{code, "z0emzbkxx1", "Z0EM ZBKX X1", "zOem-zbkx-xl", "Z0EM--ZBKX-XI"} {
+		parsed, err := ParseSupportCode(typed)
+		ExpectedActual(t, nil, err, typed+" error")
+		ExpectedActual(t, code, parsed, typed)
+		ExpectedActual(t, true, id.MatchesSupportCode(typed), typed+" matches")
+	}
+
+	// Look-alike characters are fixed.
+	zeroCode := Nil.SupportCode(5)
+	ExpectedActual(t, "0000-00", zeroCode, "zero")
+	parsed, err := ParseSupportCode("oOoo-00")
+	ExpectedActual(t, nil, err, "O for 0 error")
+	ExpectedActual(t, zeroCode, parsed, "O for 0")
+	oneCode := RandomID{0: 0x08}.SupportCode(2) // The first 5 bits are 00001
+	ExpectedActual(t, true, strings.HasPrefix(oneCode, "1"), "one")
+	for _, lookalike := range []string{"I", "i", "L", "l"} {
+		parsed, err := ParseSupportCode(lookalike + oneCode[1:])
+		ExpectedActual(t, nil, err, lookalike+" error")
+		ExpectedActual(t, oneCode, parsed, lookalike)
+	}
+
+	_, err = ParseSupportCode("Z0EM-ZBKX-X2")
+	ExpectedActual(t, true, errors.Is(err, ErrBadCheckCharacter), "bad check")
+	ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "bad check is invalid")
+	for _, bad := range []string{"", "7", "Z0EM-ZBKX-XU", "Z0EM_ZBKX_X1", strings.Repeat("0", 28)} {
+		_, err := ParseSupportCode(bad)
+		ExpectedActual(t, true, errors.Is(err, ErrInvalidID), "invalid "+bad)
+	}
+	ExpectedActual(t, false, id.MatchesSupportCode(NewRandom().SupportCode(9)), "other id")
+	ExpectedActual(t, false, id.MatchesSupportCode("Z0EM-ZBKX-X2"), "bad check doesn't match")
+}
+
+// Every single character mistake and every adjacent swap must be caught, including ones involving the check character.
+func TestSupportCodeCatchesTypos(t *testing.T) {
+	gen := NewGenerator(entropy.Seeded(4))
+	for range 200 {
+		code := strings.ReplaceAll(gen.NewRandom().SupportCode(10), "-", "")
+		for i := range len(code) {
+			for _, c := range crockfordAlphabet {
+				if byte(c) == code[i] {
+					continue
+				}
+				typo := code[:i] + string(c) + code[i+1:]
+				if _, err := ParseSupportCode(typo); !ExpectedActual(t, true, errors.Is(err, ErrBadCheckCharacter), "substitution "+code+" -> "+typo) {
+					return
+				}
+			}
+			if i+1 < len(code) && code[i] != code[i+1] {
+				swapped := code[:i] + code[i+1:i+2] + code[i:i+1] + code[i+2:]
+				if _, err := ParseSupportCode(swapped); !ExpectedActual(t, true, errors.Is(err, ErrBadCheckCharacter), "swap "+code+" -> "+swapped) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package uuid
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/seanpfeifer/rigging/assert"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestV7(t *testing.T) {
+	clock := &fakeClock{now: time.UnixMilli(1469918176385)}
+	gen := NewV7Generator(clock.Now)
+
+	id := gen.New()
+	ExpectedActual(t, 7, id.Version(), "version")
+	ExpectedActual(t, true, id.IsRFC(), "variant")
+	ExpectedActual(t, clock.Now(), id.Timestamp(), "timestamp")
+	ExpectedActual(t, "01ARYZ6S41", id.ULID()[:10], "ulid timestamp")
+	ExpectedActual(t, "01563df3-6481-7", id.Canonical()[:15], "canonical timestamp")
+
+	prev := id
+	for i := range 10_000 {
+		if i%1000 == 0 {
+			clock.Add(-time.Second) // The clock going backwards must not break the ordering
+		}
+		if i%3000 == 0 {
+			clock.Add(10 * time.Second)
+		}
+		next := gen.New()
+		if !ExpectedActual(t, -1, bytes.Compare(prev[:], next[:]), "increasing") ||
+			!ExpectedActual(t, true, prev.Canonical() < next.Canonical(), "canonical sorts") ||
+			!ExpectedActual(t, true, prev.ULID() < next.ULID(), "ulid sorts") ||
+			!ExpectedActual(t, 7, next.Version(), "version after increment") ||
+			!ExpectedActual(t, true, next.IsRFC(), "variant after increment") {
+			return
+		}
+		prev = next
+	}
+}
+
+func TestV7Overflow(t *testing.T) {
+	clock := &fakeClock{now: time.UnixMilli(1000)}
+	gen := NewV7Generator(clock.Now)
+	gen.New()
+	// Force the counter to its max value, so the next ID has to move to the next millisecond.
+	for i := 6; i < 16; i++ {
+		gen.last[i] = 0xff
+	}
+	gen.last.setVersion(7)
+	id := gen.New()
+	ExpectedActual(t, time.UnixMilli(1001), id.Timestamp(), "overflow moves to the next millisecond")
+	ExpectedActual(t, 7, id.Version(), "version")
+}
+
+func TestV7Epoch(t *testing.T) {
+	for _, start := range []time.Time{time.Unix(0, 0), time.Unix(-3600, 0)} {
+		a := NewV7Generator(func() time.Time { return start }).New()
+		b := NewV7Generator(func() time.Time { return start }).New()
+		ExpectedActual(t, false, a == b, "fresh generators get random bits")
+		ExpectedActual(t, time.UnixMilli(0), a.Timestamp(), "clamped to the epoch")
+		ExpectedActual(t, 7, a.Version(), "version")
+	}
+
+	clock := &fakeClock{now: time.Unix(-1, 0)}
+	gen := NewV7Generator(clock.Now)
+	first := gen.New()
+	clock.Add(2 * time.Second)
+	second := gen.New()
+	ExpectedActual(t, true, bytes.Compare(first[:], second[:]) < 0, "still increasing after crossing the epoch")
+}
+
+func TestV7Concurrent(t *testing.T) {
+	const workers, perWorker = 8, 1000
+	results := make([][]RandomID, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			for range perWorker {
+				results[w] = append(results[w], NewV7())
+			}
+		})
+	}
+	wg.Wait()
+
+	seen := map[RandomID]bool{}
+	for _, ids := range results {
+		ExpectedActual(t, true, slices.IsSortedFunc(ids, func(a, b RandomID) int { return bytes.Compare(a[:], b[:]) }), "sorted per goroutine")
+		for _, id := range ids {
+			seen[id] = true
+		}
+	}
+	ExpectedActual(t, workers*perWorker, len(seen), "unique")
+}
+
+func TestULID(t *testing.T) {
+	id := NewV7()
+	s := id.ULID()
+	ExpectedActual(t, 26, len(s), "length")
+	back, err := ParseULID(s)
+	ExpectedActual(t, nil, err, "parse error")
+	ExpectedActual(t, id, back, "round trip")
+
+	lower, err := ParseULID("01arz3ndektsv4rrffq69g5fav")
+	ExpectedActual(t, nil, err, "lowercase error")
+	ExpectedActual(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", lower.ULID(), "lowercase")
+	maxID := RandomID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	ExpectedActual(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", maxID.ULID(), "max")
+
+	for _, bad := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "81ARZ3NDEKTSV4RRFFQ69G5FAV"} {
+		_, err := ParseULID(bad)
+		ExpectedActual(t, true, err != nil, "invalid "+bad)
+	}
+}